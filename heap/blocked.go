@@ -0,0 +1,75 @@
+package heap
+
+import "cmp"
+
+const blockHeight = 3 // levels of the heap per block, at least 1
+
+// SortCacheAware uses the Heapsort algorithm to sort a slice,
+// with the heap laid out in blocks, like Poul-Henning Kamp's B-heap.
+//
+// In the classic layout, the children of element i are elements 2i+1 and 2i+2,
+// so each level of a sift down touches memory further away from the last.
+// In the blocked layout, each block holds a complete subtree of a few levels,
+// so a sift down touches fewer cache lines (or pages) on huge slices.
+// Blocks follow each other in breadth-first order,
+// so, like in the classic layout, parents come before their children,
+// and the heap can shrink from the end as it's sorted.
+// The extra index arithmetic has a cost, though:
+// with 10 million ints, BenchmarkSortCacheAware finds it 20 to 30% slower than Sort.
+// It can only pay off when misses are much more costly,
+// like for slices larger than memory, or backed by a memory mapped file;
+// the blocked.n flag sets the size to benchmark.
+// It uses O(n·log(n)) time and O(1) space.
+func SortCacheAware[T cmp.Ordered](s []T) {
+	for i := len(s) - 1; i >= 0; i -= 1 {
+		blockSiftDown(s, i)
+	}
+
+	m := len(s)
+	for m > 1 {
+		m -= 1
+		s[0], s[m] = s[m], s[0]
+		blockSiftDown(s[:m], 0)
+	}
+}
+
+// Blocks hold complete binary trees of blockHeight levels.
+// Each of a block's leaves has two child blocks.
+const (
+	blockSize   = 1<<blockHeight - 1 // elements per block
+	blockInner  = blockSize / 2      // inner elements per block
+	blockFanout = blockSize + 1      // child blocks per block
+)
+
+// BlockChild returns the index of the left child of element i;
+// the right child follows it in the same block, or in the next block.
+func blockChild(i int) (l, r int) {
+	b, j := i/blockSize, i%blockSize
+	if j < blockInner {
+		return i + j + 1, i + j + 2
+	}
+	c := b*blockFanout + 1 + 2*(j-blockInner)
+	return c * blockSize, (c + 1) * blockSize
+}
+
+// BlockSiftDown moves element i down the max-heap,
+// until its children aren't greater.
+// Unlike siftDown, it doesn't use Floyd's bottom-up method,
+// which, climbing back up, touches the blocks twice.
+// It uses O(log(n)) time and O(1) space.
+func blockSiftDown[T cmp.Ordered](s []T, i int) {
+	for {
+		l, r := blockChild(i)
+		if l >= len(s) {
+			return
+		}
+		if r < len(s) && cmp.Less(s[l], s[r]) {
+			l = r
+		}
+		if !cmp.Less(s[i], s[l]) {
+			return
+		}
+		s[i], s[l] = s[l], s[i]
+		i = l
+	}
+}
@@ -0,0 +1,89 @@
+package heap
+
+import (
+	"flag"
+	"slices"
+	"testing"
+)
+
+var benchSize = flag.Int("blocked.n", 10_000_000, "elements to sort in BenchmarkSortCacheAware (try 500_000_000)")
+
+func TestSortCacheAware(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(1_000_000)},
+		{"bits", bits(1_000_000)},
+		{"sorted", sorted(1_000_000)},
+		{"reversed", reversed(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+		{"permutation", permutation(1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortCacheAware(tt.list)
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+
+	// Every length, to cover partial blocks.
+	for n := 0; n < 1000; n++ {
+		list := permutation(n)
+		SortCacheAware(list)
+		if !slices.Equal(list, sorted(n)) {
+			t.Fatalf("n=%d: not sorted", n)
+		}
+	}
+}
+
+func TestBlockChild(t *testing.T) {
+	// Each element, but the root, is the child of exactly one element,
+	// which comes before it.
+	const n = 100_000
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = -1
+	}
+	for i := 0; i < n; i++ {
+		l, r := blockChild(i)
+		if r != l+1 && r != l+blockSize {
+			t.Fatalf("children of %d: %d, %d", i, l, r)
+		}
+		for _, c := range []int{l, r} {
+			if c <= i {
+				t.Fatalf("child %d of %d", c, i)
+			}
+			if c < n {
+				if parent[c] >= 0 {
+					t.Fatalf("%d has parents %d and %d", c, parent[c], i)
+				}
+				parent[c] = i
+			}
+		}
+	}
+	for i, p := range parent[1:] {
+		if p < 0 {
+			t.Fatalf("%d has no parent", i+1)
+		}
+	}
+}
+
+func BenchmarkSortCacheAware(b *testing.B) {
+	src := permutation(*benchSize)
+	list := make([]int, len(src))
+	b.Run("Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			Sort(list)
+		}
+	})
+	b.Run("SortCacheAware", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortCacheAware(list)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package heap
+
+// SortFunc uses the Heapsort algorithm to sort a slice,
+// as determined by the cmp function, configured by opts.
+// It uses O(n·log(n)) time and O(1) space.
+func SortFunc[T any](s []T, cmp func(a, b T) int, opts ...Option) {
+	var c config
+	for _, o := range opts {
+		o(&c)
+	}
+	if c.sift == Classic {
+		sortClassicFunc(s, cmp)
+		return
+	}
+
+	heapifyFunc(s, cmp)
+
+	m := len(s)
+	for m > 1 {
+		m -= 1
+		s[0], s[m] = s[m], s[0]
+		siftDownFunc(s[:m], 0, cmp)
+	}
+}
+
+// SortStableFunc uses the Heapsort algorithm to sort a slice,
+// as determined by the cmp function,
+// keeping equal elements in their original order.
+// Heapsort isn't stable, so this tags elements with their original index,
+// and uses it to break ties.
+// It uses O(n·log(n)) time and O(n) space.
+func SortStableFunc[T any](s []T, cmp func(a, b T) int) {
+	x := make([]indexed[T], len(s))
+	for i, v := range s {
+		x[i] = indexed[T]{v, i}
+	}
+
+	SortFunc(x, func(a, b indexed[T]) int {
+		if c := cmp(a.v, b.v); c != 0 {
+			return c
+		}
+		return a.i - b.i
+	})
+
+	for i, e := range x {
+		s[i] = e.v
+	}
+}
+
+// An indexed element remembers its original position.
+type indexed[T any] struct {
+	v T
+	i int
+}
+
+func heapifyFunc[T any](s []T, cmp func(a, b T) int) {
+	for i := len(s)/2 - 1; i >= 0; i -= 1 {
+		siftDownFunc(s, i, cmp)
+	}
+}
+
+func siftDownFunc[T any](s []T, i int, cmp func(a, b T) int) {
+	j := minSearchFunc(s, i, cmp)
+	for cmp(s[j], s[i]) < 0 {
+		j = (j - 1) / 2
+	}
+	for j > i {
+		s[j], s[i] = s[i], s[j]
+		j = (j - 1) / 2
+	}
+}
+
+func minSearchFunc[T any](s []T, j int, cmp func(a, b T) int) int {
+	for {
+		l := 2*j + 1
+		r := 2*j + 2
+		switch {
+		case r > len(s):
+			return j
+		case r == len(s):
+			return l
+		}
+		if cmp(s[l], s[r]) < 0 {
+			j = r
+		} else {
+			j = l
+		}
+	}
+}
+
+func sortClassicFunc[T any](s []T, cmp func(a, b T) int) {
+	for i := len(s)/2 - 1; i >= 0; i -= 1 {
+		siftDownClassicFunc(s, i, cmp)
+	}
+
+	m := len(s)
+	for m > 1 {
+		m -= 1
+		s[0], s[m] = s[m], s[0]
+		siftDownClassicFunc(s[:m], 0, cmp)
+	}
+}
+
+func siftDownClassicFunc[T any](s []T, i int, cmp func(a, b T) int) {
+	p := s[i]
+	for {
+		j := 2*i + 1
+		if j >= len(s) {
+			break
+		}
+		if r := j + 1; r < len(s) && cmp(s[j], s[r]) < 0 {
+			j = r
+		}
+		if cmp(p, s[j]) >= 0 {
+			break
+		}
+		s[i] = s[j]
+		i = j
+	}
+	s[i] = p
+}
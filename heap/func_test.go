@@ -0,0 +1,53 @@
+package heap
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestSortFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(1_000_000)},
+		{"bits", bits(1_000_000)},
+		{"sorted", sorted(1_000_000)},
+		{"reversed", reversed(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+		{"permutation", permutation(1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortFunc(tt.list, cmp.Compare)
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestSortStableFunc(t *testing.T) {
+	type record struct{ key, id int }
+
+	list := make([]record, 100_000)
+	for i, k := range permutation(len(list)) {
+		list[i] = record{k % 100, i}
+	}
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byKey)
+
+	SortStableFunc(list, byKey)
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+}
+
+func BenchmarkSortFunc(b *testing.B) {
+	list := floats(10_000_000)
+	b.ResetTimer()
+	SortFunc(list, cmp.Compare)
+}
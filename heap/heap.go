@@ -1,4 +1,5 @@
-// Package heap implements Floyd's bottom-up Heapsort.
+// Package heap implements Floyd's bottom-up Heapsort,
+// and a priority queue built on the same binary heap.
 package heap
 
 import "cmp"
@@ -16,6 +17,66 @@ func Sort[T cmp.Ordered](s []T) {
 	}
 }
 
+// SortFirst uses a bounded binary heap to sort the first k elements of a slice.
+// It keeps the k smallest elements seen so far in a max-heap,
+// scanning the slice once, then sorts them.
+// It uses O(n·log(k)) time and O(1) space.
+func SortFirst[T cmp.Ordered](s []T, k int) {
+	// This does a bounds check before making any changes to the slice.
+	_ = s[:k]
+
+	if k == 0 {
+		return
+	}
+	heapify(s[:k])
+	for i := k; i < len(s); i += 1 {
+		if cmp.Less(s[i], s[0]) {
+			s[0], s[i] = s[i], s[0]
+			siftDown(s[:k], 0)
+		}
+	}
+	Sort(s[:k])
+}
+
+// SortLast uses the Heapsort algorithm to sort the last k elements of a slice:
+// the k largest elements, in sorted order.
+// It builds a binary max-heap, then extracts its maximum only k times,
+// placing each at the end of the slice.
+// It uses O(n + k·log(n)) time and O(1) space.
+func SortLast[T cmp.Ordered](s []T, k int) {
+	// This does a bounds check before making any changes to the slice.
+	_ = s[len(s)-k:]
+
+	heapify(s)
+
+	m := len(s)
+	for m > len(s)-k && m > 1 {
+		m -= 1
+		s[0], s[m] = s[m], s[0]
+		siftDown(s[:m], 0)
+	}
+}
+
+// Meld merges binary max-heaps, like those Heapsort builds,
+// into a new one.
+// Binary heaps can't be melded in O(log(n)) time, like some other heaps,
+// so this simply concatenates them, and heapifies the result bottom-up,
+// which is no faster than if the segments weren't heaps:
+// it's a convenience.
+// It uses O(n) time and O(n) space.
+func Meld[T cmp.Ordered](segments ...[]T) []T {
+	n := 0
+	for _, s := range segments {
+		n += len(s)
+	}
+	h := make([]T, 0, n)
+	for _, s := range segments {
+		h = append(h, s...)
+	}
+	heapify(h)
+	return h
+}
+
 // Heapify rearranges a slice into a binary max-heap.
 // It uses O(n) time and O(1) space.
 func heapify[T cmp.Ordered](s []T) {
@@ -3,6 +3,7 @@ package heap
 import (
 	"math/rand"
 	"slices"
+	"strconv"
 	"testing"
 )
 
@@ -28,12 +29,123 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSortFirst(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(1_000_000)},
+		{"bits", bits(1_000_000)},
+		{"sorted", sorted(1_000_000)},
+		{"reversed", reversed(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+		{"permutation", permutation(1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			SortFirst(tt.list, 1111)
+			if !slices.Equal(tt.list[:1111], want[:1111]) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestSortLast(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(1_000_000)},
+		{"bits", bits(1_000_000)},
+		{"sorted", sorted(1_000_000)},
+		{"reversed", reversed(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+		{"permutation", permutation(1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			n := len(tt.list)
+			SortLast(tt.list, 1111)
+			if !slices.Equal(tt.list[n-1111:], want[n-1111:]) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestMeld(t *testing.T) {
+	var segments [][]int
+	var want []int
+	for _, n := range []int{0, 1, 1000, 7, 100_000, 0} {
+		s := permutation(n)
+		want = append(want, s...)
+		heapify(s)
+		segments = append(segments, s)
+	}
+	slices.Sort(want)
+
+	h := Meld(segments...)
+	for i := 1; i < len(h); i++ {
+		if h[i] > h[(i-1)/2] {
+			t.Fatalf("not a max-heap at %d", i)
+		}
+	}
+
+	// Popping the maximum gives descending order.
+	for m := len(h) - 1; m >= 0; m-- {
+		if h[0] != want[m] {
+			t.Fatalf("got %d, want %d", h[0], want[m])
+		}
+		h[0], h[m] = h[m], h[0]
+		if m > 0 {
+			siftDown(h[:m], 0)
+		}
+	}
+
+	if h := Meld[int](); len(h) != 0 {
+		t.Errorf("got %v", h)
+	}
+}
+
+func TestBounds(t *testing.T) {
+	Sort[int](nil)
+	Sort([]int{0})
+
+	SortFirst[int](nil, 0)
+	SortFirst([]int{0}, 0)
+	SortFirst([]int{0}, 1)
+
+	SortLast[int](nil, 0)
+	SortLast([]int{0}, 0)
+	SortLast([]int{0}, 1)
+}
+
 func BenchmarkSort(b *testing.B) {
 	list := floats(10_000_000)
 	b.ResetTimer()
 	Sort(list)
 }
 
+func BenchmarkSortLast(b *testing.B) {
+	src := floats(1_000_000)
+	list := make([]float64, len(src))
+	for _, k := range []int{1, 16, 1024, 65536, len(src)} {
+		b.Run(strconv.Itoa(k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortLast(list, k)
+			}
+		})
+	}
+}
+
 func zeros(n int) []int {
 	return make([]int, n)
 }
@@ -0,0 +1,58 @@
+package heap
+
+// An Interface is a collection of elements accessed by index,
+// like sort.Interface.
+type Interface interface {
+	Len() int
+	Less(i, j int) bool
+	Swap(i, j int)
+}
+
+// SortInterface uses the Heapsort algorithm to sort data.
+// It accesses elements only through data's methods,
+// so it can sort collections that aren't slices.
+// It uses O(n·log(n)) time and O(1) space.
+func SortInterface(data Interface) {
+	n := data.Len()
+	for i := n/2 - 1; i >= 0; i -= 1 {
+		siftDownInterface(data, i, n)
+	}
+
+	m := n
+	for m > 1 {
+		m -= 1
+		data.Swap(0, m)
+		siftDownInterface(data, 0, m)
+	}
+}
+
+// SiftDownInterface is siftDown for the first n elements of data.
+func siftDownInterface(data Interface, i, n int) {
+	j := minSearchInterface(data, i, n)
+	for data.Less(j, i) {
+		j = (j - 1) / 2
+	}
+	for j > i {
+		data.Swap(j, i)
+		j = (j - 1) / 2
+	}
+}
+
+// MinSearchInterface is minSearch for the first n elements of data.
+func minSearchInterface(data Interface, j, n int) int {
+	for {
+		l := 2*j + 1
+		r := 2*j + 2
+		switch {
+		case r > n:
+			return j
+		case r == n:
+			return l
+		}
+		if data.Less(l, r) {
+			j = r
+		} else {
+			j = l
+		}
+	}
+}
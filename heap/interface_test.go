@@ -0,0 +1,43 @@
+package heap
+
+import (
+	"slices"
+	"testing"
+)
+
+type ints []int
+
+func (s ints) Len() int           { return len(s) }
+func (s ints) Less(i, j int) bool { return s[i] < s[j] }
+func (s ints) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func TestSortInterface(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(1_000_000)},
+		{"bits", bits(1_000_000)},
+		{"sorted", sorted(1_000_000)},
+		{"reversed", reversed(1_000_000)},
+		{"rotated", rotated(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+		{"permutation", permutation(1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			Sort(want)
+
+			SortInterface(ints(tt.list))
+			if !slices.Equal(tt.list, want) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func rotated(n int) []int {
+	s := sorted(n)
+	return append(s[n/3:], s[:n/3]...)
+}
@@ -0,0 +1,60 @@
+package heap
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Merge appends to out the elements of the sorted slices in, in sorted order,
+// and returns the extended slice.
+// Equal elements keep their order: those from earlier slices come first.
+// It uses O(n·log(k)) time and O(k) space, for k slices.
+func Merge[T cmp.Ordered](out []T, in ...[]T) []T {
+	return MergeFunc(cmp.Compare[T], out, in...)
+}
+
+// MergeFunc appends to out the elements of the slices in,
+// which must be sorted as determined by the cmp function,
+// in sorted order, and returns the extended slice.
+// Equal elements keep their order: those from earlier slices come first.
+// It uses O(n·log(k)) time and O(k) space, for k slices.
+func MergeFunc[T any](cmp func(a, b T) int, out []T, in ...[]T) []T {
+	// Skip empty slices, so the heap only holds slices with a head.
+	n := 0
+	h := make([]indexed[[]T], 0, len(in))
+	for i, s := range in {
+		if len(s) > 0 {
+			h = append(h, indexed[[]T]{s, i})
+			n += len(s)
+		}
+	}
+	out = slices.Grow(out, n)
+
+	// The heap is ordered by the head of each slice.
+	// The algorithms are for a max-heap, so reverse the order.
+	less := func(a, b indexed[[]T]) int {
+		if c := cmp(b.v[0], a.v[0]); c != 0 {
+			return c
+		}
+		return b.i - a.i
+	}
+	heapifyFunc(h, less)
+
+	for len(h) > 1 {
+		t := &h[0]
+		out = append(out, t.v[0])
+		t.v = t.v[1:]
+		if len(t.v) == 0 {
+			m := len(h) - 1
+			h[0] = h[m]
+			h = h[:m]
+		}
+		siftDownFunc(h, 0, less)
+	}
+
+	// A single slice left needs no merging.
+	if len(h) == 1 {
+		out = append(out, h[0].v...)
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package heap
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	var in [][]int
+	var want []int
+	for i, n := range []int{0, 1000, 1, 0, 50_000, 333, 0} {
+		s := permutation(n)
+		for j := range s {
+			s[j] %= 100 + i
+		}
+		slices.Sort(s)
+		in = append(in, s)
+		want = append(want, s...)
+	}
+	slices.Sort(want)
+
+	got := Merge([]int{-1}, in...)
+	if got[0] != -1 || !slices.Equal(got[1:], want) {
+		t.FailNow()
+	}
+	if got := Merge[int](nil); len(got) != 0 {
+		t.FailNow()
+	}
+	if got := Merge(nil, nil, in[1], nil); !slices.Equal(got, in[1]) {
+		t.FailNow()
+	}
+}
+
+func TestMergeFunc(t *testing.T) {
+	type record struct{ key, shard, id int }
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+	var in [][]record
+	var want []record
+	for shard := 0; shard < 8; shard += 1 {
+		s := make([]record, 10_000)
+		for i, k := range permutation(len(s)) {
+			s[i] = record{k % 100, shard, i}
+		}
+		slices.SortStableFunc(s, byKey)
+		in = append(in, s)
+		want = append(want, s...)
+	}
+	slices.SortStableFunc(want, byKey)
+
+	// Ties come from earlier shards first, in their original order.
+	got := MergeFunc(byKey, nil, in...)
+	if !slices.Equal(got, want) {
+		t.FailNow()
+	}
+}
+
+func BenchmarkMergeFunc(b *testing.B) {
+	in := make([][]float64, 16)
+	for i := range in {
+		in[i] = floats(100_000)
+		slices.Sort(in[i])
+	}
+	out := make([]float64, 0, 16*100_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		MergeFunc(cmp.Compare[float64], out, in...)
+	}
+}
@@ -0,0 +1,84 @@
+package heap
+
+import "cmp"
+
+// An Option configures the behavior of SortWith and SortFunc.
+type Option func(*config)
+
+type config struct {
+	sift Sift
+}
+
+// A Sift is a strategy to sift elements down the heap.
+type Sift int
+
+const (
+	// Floyd sifts down to a leaf, always following the larger child,
+	// then back up to where the element goes.
+	// It takes about half the comparisons of Classic,
+	// so it pays off when comparisons are expensive.
+	// It's the default.
+	Floyd Sift = iota
+	// Classic sifts down comparing the element with the larger child,
+	// and stops where it goes.
+	// It takes more comparisons, but shifts elements, rather than swap them,
+	// so it pays off when comparisons are cheap:
+	// BenchmarkSortWith finds it faster for ints.
+	Classic
+)
+
+// WithSift selects the strategy to sift elements down the heap.
+func WithSift(s Sift) Option {
+	return func(c *config) { c.sift = s }
+}
+
+// SortWith uses the Heapsort algorithm to sort a slice,
+// configured by opts.
+// It uses O(n·log(n)) time and O(1) space.
+func SortWith[T cmp.Ordered](s []T, opts ...Option) {
+	var c config
+	for _, o := range opts {
+		o(&c)
+	}
+	if c.sift == Classic {
+		sortClassic(s)
+	} else {
+		Sort(s)
+	}
+}
+
+// SortClassic is Heapsort with classic sifting.
+func sortClassic[T cmp.Ordered](s []T) {
+	for i := len(s)/2 - 1; i >= 0; i -= 1 {
+		siftDownClassic(s, i)
+	}
+
+	m := len(s)
+	for m > 1 {
+		m -= 1
+		s[0], s[m] = s[m], s[0]
+		siftDownClassic(s[:m], 0)
+	}
+}
+
+// SiftDownClassic moves s[i] down the heap, until neither child is larger,
+// shifting the larger child up at each step.
+// It uses O(log(n)) time and O(1) space.
+func siftDownClassic[T cmp.Ordered](s []T, i int) {
+	p := s[i]
+	for {
+		j := 2*i + 1
+		if j >= len(s) {
+			break
+		}
+		if r := j + 1; r < len(s) && cmp.Less(s[j], s[r]) {
+			j = r
+		}
+		if !cmp.Less(p, s[j]) {
+			break
+		}
+		s[i] = s[j]
+		i = j
+	}
+	s[i] = p
+}
@@ -0,0 +1,73 @@
+package heap
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestSortWith(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, sift := range []struct {
+		name string
+		Sift
+	}{{"Floyd", Floyd}, {"Classic", Classic}} {
+		for _, tt := range tests {
+			t.Run(sift.name+"/"+tt.name, func(t *testing.T) {
+				list := slices.Clone(tt.list)
+				SortWith(list, WithSift(sift.Sift))
+				if !slices.IsSorted(list) {
+					t.FailNow()
+				}
+
+				list = slices.Clone(tt.list)
+				SortFunc(list, cmp.Compare, WithSift(sift.Sift))
+				if !slices.IsSorted(list) {
+					t.FailNow()
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSortWith(b *testing.B) {
+	type large struct {
+		key int
+		_   [120]byte
+	}
+	byKey := func(a, b large) int { return cmp.Compare(a.key, b.key) }
+
+	src := permutation(1_000_000)
+	ints := make([]int, len(src))
+	larges := make([]large, len(src))
+
+	for _, sift := range []struct {
+		name string
+		Sift
+	}{{"Floyd", Floyd}, {"Classic", Classic}} {
+		b.Run("int/"+sift.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(ints, src)
+				SortWith(ints, WithSift(sift.Sift))
+			}
+		})
+		b.Run("large/"+sift.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j, k := range src {
+					larges[j].key = k
+				}
+				SortFunc(larges, byKey, WithSift(sift.Sift))
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package heap
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+const (
+	minParallel = 1 << 16 // elements per goroutine
+	oversample  = 8       // samples per splitter
+)
+
+// MergeParallel is like Merge, but splits the output in ranges
+// that are merged concurrently.
+// It uses O(n·log(k)/p) time with p processors, and O(k·p) space.
+func MergeParallel[T cmp.Ordered](out []T, in ...[]T) []T {
+	return MergeParallelFunc(cmp.Compare[T], out, in...)
+}
+
+// MergeParallelFunc is like MergeFunc, but splits the output in ranges
+// that are merged concurrently.
+// The ranges are bounded by splitters, sampled from the slices in,
+// and located in each of them by binary search.
+// The result is the same as for MergeFunc.
+// It uses O(n·log(k)/p) time with p processors, and O(k·p) space.
+func MergeParallelFunc[T any](cmp func(a, b T) int, out []T, in ...[]T) []T {
+	var n int
+	for _, s := range in {
+		n += len(s)
+	}
+	p := min(runtime.GOMAXPROCS(0), n/minParallel)
+	if p < 2 {
+		return MergeFunc(cmp, out, in...)
+	}
+
+	// Sample the slices, and pick p-1 splitters from the samples.
+	var samples []T
+	for _, s := range in {
+		m := min(len(s), p*oversample*len(s)/n+1)
+		for i := 0; i < m && len(s) > 0; i += 1 {
+			samples = append(samples, s[i*len(s)/m])
+		}
+	}
+	SortFunc(samples, cmp)
+
+	// Range j takes, from each slice, the elements from splitter j-1 up to splitter j.
+	// Equal elements are never split, so the merge stays stable.
+	bounds := make([][]int, p+1)
+	bounds[0] = make([]int, len(in))
+	bounds[p] = make([]int, len(in))
+	for i, s := range in {
+		bounds[p][i] = len(s)
+	}
+	for j := 1; j < p; j += 1 {
+		splitter := samples[j*len(samples)/p]
+		bounds[j] = make([]int, len(in))
+		for i, s := range in {
+			bounds[j][i], _ = slices.BinarySearchFunc(s, splitter, cmp)
+		}
+	}
+
+	m := len(out)
+	out = slices.Grow(out, n)[:m+n]
+	dst := out[m:]
+
+	var wg sync.WaitGroup
+	for j := 0; j < p; j += 1 {
+		parts := make([][]T, len(in))
+		size := 0
+		for i, s := range in {
+			parts[i] = s[bounds[j][i]:bounds[j+1][i]]
+			size += len(parts[i])
+		}
+		wg.Add(1)
+		go func(dst []T) {
+			defer wg.Done()
+			MergeFunc(cmp, dst[:0], parts...)
+		}(dst[:size])
+		dst = dst[size:]
+	}
+	wg.Wait()
+	return out
+}
@@ -0,0 +1,71 @@
+package heap
+
+import (
+	"cmp"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMergeParallel(t *testing.T) {
+	// Split the output even if there's a single processor.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	for _, k := range []int{1, 2, 64} {
+		in := make([][]int, k)
+		for i := range in {
+			in[i] = bits(2_000_000 / k)
+			for j := range in[i] {
+				in[i][j] += j % 1000
+			}
+			slices.Sort(in[i])
+		}
+
+		want := Merge([]int{-1}, in...)
+		got := MergeParallel([]int{-1}, in...)
+		if !slices.Equal(got, want) {
+			t.Fatalf("k=%d: merges differ", k)
+		}
+	}
+
+	type record struct{ key, shard, id int }
+	byKey := func(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+	in := make([][]record, 16)
+	for shard := range in {
+		in[shard] = make([]record, 100_000)
+		for i, v := range permutation(100_000) {
+			in[shard][i] = record{v % 100, shard, i}
+		}
+		slices.SortStableFunc(in[shard], byKey)
+	}
+	if !slices.Equal(MergeParallelFunc(byKey, nil, in...), MergeFunc(byKey, nil, in...)) {
+		t.Fatal("stable merges differ")
+	}
+
+	if got := MergeParallel[int](nil); len(got) != 0 {
+		t.Fatal("not empty")
+	}
+}
+
+func BenchmarkMergeParallel(b *testing.B) {
+	in := make([][]float64, 64)
+	for i := range in {
+		in[i] = floats(100_000)
+		slices.Sort(in[i])
+	}
+	out := make([]float64, 0, 64*100_000)
+
+	for _, parallel := range []bool{false, true} {
+		b.Run(strconv.FormatBool(parallel), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if parallel {
+					MergeParallel(out, in...)
+				} else {
+					Merge(out, in...)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package heap
+
+import "cmp"
+
+// A Heap is a priority queue backed by a binary min-heap:
+// Pop removes elements in ascending order.
+// The zero value is an empty heap ready to use.
+type Heap[T cmp.Ordered] struct {
+	s []T
+}
+
+// Adopt takes ownership of a slice, rearranging it in place into a heap,
+// which uses it as its backing array.
+// The caller must not use the slice afterwards.
+// It uses O(n) time and O(1) space.
+func Adopt[T cmp.Ordered](s []T) *Heap[T] {
+	heapifyFunc(s, reverse[T])
+	return &Heap[T]{s}
+}
+
+// NewHeapWithCapacity returns an empty heap,
+// with room for capacity elements before it needs to grow.
+func NewHeapWithCapacity[T cmp.Ordered](capacity int) *Heap[T] {
+	return &Heap[T]{make([]T, 0, capacity)}
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.s)
+}
+
+// Peek returns the smallest element, without removing it.
+// It uses O(1) time and O(1) space.
+func (h *Heap[T]) Peek() T {
+	return h.s[0]
+}
+
+// Push adds an element to the heap.
+// It uses O(log(n)) time and amortized O(1) space.
+func (h *Heap[T]) Push(v T) {
+	h.s = append(h.s, v)
+	siftUpFunc(h.s, len(h.s)-1, reverse[T])
+}
+
+// Pop removes and returns the smallest element.
+// It uses O(log(n)) time and O(1) space.
+func (h *Heap[T]) Pop() T {
+	m := len(h.s) - 1
+	v := h.s[0]
+	h.s[0] = h.s[m]
+	h.s = h.s[:m]
+	if m > 0 {
+		siftDownFunc(h.s, 0, reverse[T])
+	}
+	return v
+}
+
+// At returns a pointer to element i of the heap, 0 ≤ i < Len().
+// The smallest element is at index 0.
+// After changing an element, call Fix to restore the heap.
+func (h *Heap[T]) At(i int) *T {
+	return &h.s[i]
+}
+
+// Fix restores the heap after element i changed.
+// It uses O(log(n)) time and O(1) space.
+func (h *Heap[T]) Fix(i int) {
+	siftUpFunc(h.s, i, reverse[T])
+	siftDownFunc(h.s, i, reverse[T])
+}
+
+// FixRoot restores the heap after the smallest element changed.
+// It uses O(log(n)) time and O(1) space.
+func (h *Heap[T]) FixRoot() {
+	siftDownFunc(h.s, 0, reverse[T])
+}
+
+// Reset removes all elements from the heap,
+// keeping its backing array for reuse.
+func (h *Heap[T]) Reset() {
+	h.s = h.s[:0]
+}
+
+// Reverse turns the max-heap algorithms into min-heap ones.
+func reverse[T cmp.Ordered](a, b T) int {
+	return cmp.Compare(b, a)
+}
+
+// SiftUp moves a leaf up the heap, until its parent isn't smaller.
+// It uses O(log(n)) time and O(1) space.
+func siftUpFunc[T any](s []T, i int, cmp func(a, b T) int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if cmp(s[p], s[i]) >= 0 {
+			break
+		}
+		s[p], s[i] = s[i], s[p]
+		i = p
+	}
+}
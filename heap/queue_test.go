@@ -0,0 +1,104 @@
+package heap
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestAdopt(t *testing.T) {
+	list := permutation(100_000)
+	h := Adopt(list)
+
+	if h.Len() != len(list) || &h.s[0] != &list[0] {
+		t.Fatal("not in place")
+	}
+	for i := range list {
+		if v := h.Pop(); v != i {
+			t.Fatalf("got %d, want %d", v, i)
+		}
+	}
+	if h.Len() != 0 {
+		t.FailNow()
+	}
+}
+
+func TestHeap(t *testing.T) {
+	var h Heap[int]
+	list := permutation(100_000)
+	for _, v := range list {
+		h.Push(v % 1000)
+	}
+
+	var got []int
+	for h.Len() > 0 {
+		v := h.Peek()
+		if h.Pop() != v {
+			t.FailNow()
+		}
+		got = append(got, v)
+	}
+
+	for i := range list {
+		list[i] %= 1000
+	}
+	slices.Sort(list)
+	if !slices.Equal(got, list) {
+		t.FailNow()
+	}
+}
+
+func TestHeap_Reset(t *testing.T) {
+	h := NewHeapWithCapacity[float64](1000)
+	list := floats(1000)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		h.Reset()
+		for _, v := range list {
+			h.Push(v)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("got %v allocs", allocs)
+	}
+}
+
+func TestHeap_Fix(t *testing.T) {
+	h := Adopt(permutation(100_000))
+
+	// Raise the priority of the smallest elements.
+	for i := 0; i < 1000; i++ {
+		*h.At(0) += 1_000_000
+		h.FixRoot()
+	}
+	// Change some arbitrary elements.
+	for i := 0; i < 1000; i++ {
+		j := (i * 7919) % h.Len()
+		*h.At(j) = -*h.At(j)
+		h.Fix(j)
+	}
+	if !isHeap(h.s) {
+		t.Fatal("not a heap")
+	}
+
+	prev := h.Pop()
+	for h.Len() > 0 {
+		v := h.Pop()
+		if v < prev {
+			t.Fatalf("got %d after %d", v, prev)
+		}
+		prev = v
+	}
+}
+
+func isHeap[T cmp.Ordered](s []T) bool {
+	for i := 1; i < len(s); i++ {
+		if s[i] < s[(i-1)/2] {
+			return false
+		}
+	}
+	return true
+}
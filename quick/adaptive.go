@@ -0,0 +1,149 @@
+package quick
+
+import "math"
+
+// WithAdaptivePartition makes SortFunc estimate how many duplicates s has,
+// from a sample of about √n elements,
+// and use three-way partitioning if there are many:
+// elements equal to the pivot are then set aside, and never partitioned again.
+// Otherwise, the two-way partition is faster.
+// Before that, it counts the ascending runs of s, like Runs,
+// and if there are few, long ones, it merges them,
+// like WithRunDetection, using O(n) extra space.
+func WithAdaptivePartition() Option {
+	return func(c *config) { c.adaptive = true }
+}
+
+// WithEqualRange makes SortFunc call f for each run of equal elements,
+// s[start:start+count], once it's in its final place,
+// so groups of equal elements can be processed without another pass.
+// This uses three-way partitioning, like WithAdaptivePartition,
+// which finds many of those runs as it goes.
+// Runs are reported once each, but not in order.
+func WithEqualRange(f func(start, count int)) Option {
+	return func(c *config) { c.equalRange = f }
+}
+
+// EqualRun reports s, all equal elements, as a run.
+func (q *sorter[T]) equalRun(s []T) {
+	if q.equalRange != nil && len(s) > 0 {
+		q.equalRange(q.start(s), len(s))
+	}
+}
+
+// EqualRuns reports the runs of equal elements in the sorted s,
+// which holds all elements equal to those it holds.
+// It uses O(n) time and O(1) space.
+func (q *sorter[T]) equalRuns(s []T) {
+	if q.equalRange == nil {
+		return
+	}
+	if q.deferBase {
+		q.insertion(s)
+	}
+	lo := 0
+	for i := 1; i <= len(s); i += 1 {
+		if i == len(s) || q.less(s[i-1], s[i]) {
+			q.equalRun(s[lo:i])
+			lo = i
+		}
+	}
+}
+
+// ManyDuplicates estimates if s has many duplicates:
+// if fewer than half of a sample of s are distinct.
+// It uses O(√n·log(n)) time and O(√n) space.
+func (q *sorter[T]) manyDuplicates(s []T) bool {
+	m := int(math.Sqrt(float64(len(s))))
+	if m < minLen {
+		return false
+	}
+	sample := make([]T, m)
+	for i := range sample {
+		sample[i] = s[i*(len(s)-1)/(m-1)]
+	}
+	t := &sorter[T]{cmp: q.cmp}
+	t.sort(sample)
+
+	distinct := 1
+	for i := 1; i < m; i += 1 {
+		if q.less(sample[i-1], sample[i]) {
+			distinct += 1
+		}
+	}
+	return distinct < m/2
+}
+
+// Sort3 is Quicksort with three-way partitioning.
+// Bad pivots are handled by sorting the larger side with sort.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func (q *sorter[T]) sort3(s []T) {
+	for len(s) > q.insertionLen() && !q.stopped(len(s)) {
+		lt, gt := q.partition3(s)
+		q.placed(gt - lt)
+		q.equalRun(s[lt:gt])
+		lo, hi := s[:lt], s[gt:]
+		if len(lo) > len(hi) {
+			lo, hi = hi, lo
+		}
+		q.sort3(lo)
+		if b := len(s) / minRatio; len(lo) < b && len(hi) > len(s)-2*b {
+			q.sort(hi)
+			q.equalRuns(hi)
+			return
+		}
+		s = hi
+	}
+	if len(s) <= q.insertionLen() {
+		q.insertion(s)
+		q.placed(len(s))
+		q.equalRuns(s)
+	}
+}
+
+// Partition3 partitions s around the median of 3 elements,
+// using Dijkstra's Dutch national flag algorithm,
+// into s[:lt] < pivot, s[lt:gt] == pivot, s[gt:] > pivot.
+// It uses O(n) time and O(1) space.
+func (q *sorter[T]) partition3(s []T) (lt, gt int) {
+	if p := q.profile; p != nil {
+		p.Partitions += 1
+	}
+
+	r := len(s) - 1
+	a, b, c := s[0], s[r/2], s[r]
+	if q.less(b, a) {
+		a, b = b, a
+	}
+	if q.less(c, b) {
+		b = c
+		if q.less(b, a) {
+			b = a
+		}
+	}
+	p := b
+
+	lt, gt = 0, len(s)
+	for i := 0; i < gt; {
+		if pr := q.profile; pr != nil {
+			pr.Comparisons += 1
+		}
+		switch c := q.cmp(s[i], p); {
+		case c < 0:
+			if lt != i {
+				q.swap(s, lt, i)
+			}
+			lt += 1
+			i += 1
+		case c > 0:
+			gt -= 1
+			if gt != i {
+				q.swap(s, gt, i)
+			}
+		default:
+			i += 1
+		}
+	}
+	q.tracePivot(s, p, lt)
+	return lt, gt
+}
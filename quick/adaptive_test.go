@@ -0,0 +1,131 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestWithAdaptivePartition(t *testing.T) {
+	tests := []struct {
+		name  string
+		list  []int
+		three bool
+	}{
+		{"zeros", zeros(100_000), true},
+		{"bits", bits(100_000), true},
+		{"low", cardinality(100_000, 100), true},
+		{"high", cardinality(100_000, 100_000), false},
+		{"sorted", sorted(100_000), false},
+		{"reversed", reversed(100_000), false},
+		{"pipeorgan", pipeorgan(100_000), false},
+		{"permutation", permutation(100_000), false},
+		{"killer", killer(128*1024 - 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := newSorter(cmp.Compare[int], nil)
+			if got := q.manyDuplicates(tt.list); got != tt.three {
+				t.Errorf("manyDuplicates = %v, want %v", got, tt.three)
+			}
+
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+			SortFunc(tt.list, cmp.Compare, WithAdaptivePartition())
+			if !slices.Equal(tt.list, want) {
+				t.Fatal("not sorted")
+			}
+		})
+	}
+
+	// Three-way partitioning handles any input.
+	for _, list := range [][]int{sorted(100_000), pipeorgan(100_000), permutation(100_000), killer(128*1024 - 1)} {
+		newSorter(cmp.Compare[int], nil).sort3(list)
+		if !slices.IsSorted(list) {
+			t.Fatal("not sorted")
+		}
+	}
+}
+
+func BenchmarkWithAdaptivePartition(b *testing.B) {
+	for _, tt := range []struct {
+		name string
+		list []int
+	}{
+		{"low", cardinality(1_000_000, 100)},
+		{"high", cardinality(1_000_000, 1_000_000)},
+	} {
+		list := make([]int, len(tt.list))
+		q := newSorter(cmp.Compare[int], nil)
+		b.Run(tt.name+"/two-way", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, tt.list)
+				q.sort(list)
+			}
+		})
+		b.Run(tt.name+"/three-way", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, tt.list)
+				q.sort3(list)
+			}
+		})
+		b.Run(tt.name+"/adaptive", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, tt.list)
+				SortFunc(list, cmp.Compare, WithAdaptivePartition())
+			}
+		})
+	}
+}
+
+// Cardinality returns n random elements with about k distinct values.
+func cardinality(n, k int) []int {
+	s := permutation(n)
+	for i := range s {
+		s[i] %= k
+	}
+	return s
+}
+
+func TestWithEqualRange(t *testing.T) {
+	for _, k := range []int{1, 2, 10, 1000, 100_000} {
+		list := cardinality(100_000, k)
+		distinct := len(slices.Compact(sortedCopy(list)))
+
+		type run struct{ start, count int }
+		var runs []run
+		SortWith(list, WithEqualRange(func(start, count int) {
+			runs = append(runs, run{start, count})
+		}))
+		if !slices.IsSorted(list) {
+			t.Fatalf("k=%d: not sorted", k)
+		}
+		if len(runs) != distinct {
+			t.Fatalf("k=%d: got %d runs, want %d", k, len(runs), distinct)
+		}
+
+		total := 0
+		for _, r := range runs {
+			s := list[r.start : r.start+r.count]
+			if s[0] != s[len(s)-1] {
+				t.Fatalf("k=%d: run %v isn't equal", k, r)
+			}
+			if r.start > 0 && list[r.start-1] == s[0] ||
+				r.start+r.count < len(list) && list[r.start+r.count] == s[0] {
+				t.Fatalf("k=%d: run %v isn't complete", k, r)
+			}
+			total += r.count
+		}
+		if total != len(list) {
+			t.Fatalf("k=%d: got %d elements, want %d", k, total, len(list))
+		}
+	}
+
+	// Bad pivots fall back to two-way partitioning.
+	var total int
+	list := killer(128*1024 - 1)
+	SortWith(list, WithEqualRange(func(start, count int) { total += count }))
+	if !slices.IsSorted(list) || total != len(list) {
+		t.Fatalf("got %d elements, want %d", total, len(list))
+	}
+}
@@ -0,0 +1,32 @@
+package quick
+
+import "cmp"
+
+// SortAppend appends the elements of all srcs to dst,
+// sorts the appended elements, and returns the grown slice.
+// It only sorts the appended elements, not the whole of dst,
+// and doesn't modify srcs.
+// It uses O(n·log(n)) time and O(log(n)) space,
+// where n is the number of appended elements.
+func SortAppend[T cmp.Ordered](dst []T, srcs ...[]T) []T {
+	m := len(dst)
+	for _, s := range srcs {
+		dst = append(dst, s...)
+	}
+	Sort(dst[m:])
+	return dst
+}
+
+// SortInto copies src into dst, sorts the copy,
+// and returns it, as dst[:len(src)].
+// It doesn't modify src.
+// It panics if dst is shorter than src.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortInto[T cmp.Ordered](dst, src []T) []T {
+	if len(dst) < len(src) {
+		panic("quick: SortInto destination too short")
+	}
+	dst = dst[:copy(dst, src)]
+	Sort(dst)
+	return dst
+}
@@ -0,0 +1,50 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortAppend(t *testing.T) {
+	a := permutation(1000)
+	b := reversed(1000)
+	c := bits(1000)
+	srcs := [][]int{slices.Clone(a), slices.Clone(b), nil, slices.Clone(c)}
+
+	dst := SortAppend([]int{-1, 3, 2}, srcs...)
+
+	if !slices.Equal(dst[:3], []int{-1, 3, 2}) || !slices.IsSorted(dst[3:]) {
+		t.Fatal("not sorted")
+	}
+	if len(dst) != 3+len(a)+len(b)+len(c) {
+		t.Fatal("wrong length")
+	}
+	if !slices.Equal(srcs[0], a) || !slices.Equal(srcs[1], b) || !slices.Equal(srcs[3], c) {
+		t.Fatal("modified sources")
+	}
+
+	if got := SortAppend[int](nil); len(got) != 0 {
+		t.Fatal("not empty")
+	}
+}
+
+func TestSortInto(t *testing.T) {
+	src := permutation(1000)
+	want := slices.Clone(src)
+
+	dst := make([]int, 1500)
+	got := SortInto(dst, src)
+	if len(got) != len(src) || &got[0] != &dst[0] || !slices.IsSorted(got) {
+		t.Fatal("not sorted")
+	}
+	if !slices.Equal(src, want) {
+		t.Fatal("modified source")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("didn't panic")
+		}
+	}()
+	SortInto(make([]int, 999), src)
+}
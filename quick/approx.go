@@ -0,0 +1,59 @@
+package quick
+
+import (
+	"math"
+	"math/rand"
+)
+
+// An ApproxPercentile estimates percentiles of a stream of numbers,
+// from a bounded, uniform, reservoir sample of them.
+//
+// With a sample of m numbers, the rank of an estimate for percentile p
+// is off by a standard deviation of √(p·(100-p)/m) percentage points,
+// at most 50/√m (0.5 for m = 10000), and within 3 times that 99.7% of the time.
+// The error doesn't grow with the length of the stream, only with smaller samples.
+type ApproxPercentile[T Number] struct {
+	n      int
+	sample []T
+	rnd    *rand.Rand
+}
+
+// NewApproxPercentile returns an empty ApproxPercentile
+// that keeps a sample of (at least 1) m numbers.
+// The same stream always gets the same estimates.
+func NewApproxPercentile[T Number](m int) *ApproxPercentile[T] {
+	m = max(m, 1)
+	return &ApproxPercentile[T]{
+		sample: make([]T, 0, m),
+		rnd:    rand.New(rand.NewSource(1)),
+	}
+}
+
+// Add adds a number to the stream.
+// It uses O(1) time and O(1) space.
+func (a *ApproxPercentile[T]) Add(v T) {
+	a.n += 1
+	// Algorithm R: each number has the same chance of being sampled.
+	if len(a.sample) < cap(a.sample) {
+		a.sample = append(a.sample, v)
+	} else if j := a.rnd.Intn(a.n); j < len(a.sample) {
+		a.sample[j] = v
+	}
+}
+
+// Percentile estimates percentile p (in [0, 100]) of the numbers added so far,
+// with Percentile over the sample, which is exact while the sample has them all.
+// Partially sorting the sample doesn't affect which numbers get replaced,
+// so this needs no copy.
+// If no numbers were added, it returns NaN.
+// It panics if p is outside [0, 100].
+// It uses O(m) time and O(log(m)) space.
+func (a *ApproxPercentile[T]) Percentile(p float64) float64 {
+	if len(a.sample) == 0 {
+		if !(0 <= p && p <= 100) {
+			panic("quick: percentile out of range")
+		}
+		return math.NaN()
+	}
+	return Percentile(a.sample, p)
+}
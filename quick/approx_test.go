@@ -0,0 +1,47 @@
+package quick
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestApproxPercentile(t *testing.T) {
+	const m = 10_000
+	list := permutation(100_000)
+
+	a := NewApproxPercentile[int](m)
+	for _, v := range list {
+		a.Add(v)
+	}
+
+	// Within 3 standard deviations of the rank.
+	for _, p := range []float64{0, 1, 10, 25, 50, 75, 90, 99, 100} {
+		got := a.Percentile(p)
+		want := Percentile(list, p)
+		bound := 3 * math.Sqrt(p*(100-p)/m) / 100 * float64(len(list))
+		// At the extremes, the error is in the gaps the sample leaves.
+		bound = max(bound, 3*float64(len(list))/m)
+		if math.Abs(got-want) > bound {
+			t.Errorf("p=%v: got %v, want %v ± %v", p, got, want, bound)
+		}
+	}
+}
+
+func TestApproxPercentile_exact(t *testing.T) {
+	list := permutation(1000)
+
+	a := NewApproxPercentile[int](len(list))
+	for _, v := range list {
+		a.Add(v)
+	}
+	for _, p := range []float64{0, 12.5, 50, 99.9, 100} {
+		if got, want := a.Percentile(p), Percentile(slices.Clone(list), p); got != want {
+			t.Errorf("p=%v: got %v, want %v", p, got, want)
+		}
+	}
+
+	if got := NewApproxPercentile[float64](10).Percentile(50); !math.IsNaN(got) {
+		t.Errorf("got %v, want NaN", got)
+	}
+}
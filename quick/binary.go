@@ -0,0 +1,40 @@
+package quick
+
+// WithBinaryInsertion makes the Insertion sort base case
+// find where each element goes with binary search.
+// This takes O(log(n)) rather than O(n) comparisons per element,
+// but elements are still shifted one by one.
+// It pays off for expensive comparison functions.
+func WithBinaryInsertion() Option {
+	return func(c *config) { c.binaryInsertion = true }
+}
+
+// BinaryInsertionSort is Insertion sort with binary search.
+// Elements equal to the one being inserted stay before it,
+// so the sort is stable.
+// It uses O(n·log(n)) comparisons, O(n²) moves, and O(1) space.
+func (q *sorter[T]) binaryInsertionSort(s []T) {
+	for i := 1; i < len(s); i += 1 {
+		p := s[i]
+		if !q.lessAt(s, p, s[i-1], i, i-1) {
+			continue
+		}
+
+		// Find the first element greater than p, in s[:i-1].
+		lo, hi := 0, i-1
+		for lo < hi {
+			m := int(uint(lo+hi) >> 1)
+			if q.lessAt(s, p, s[m], i, m) {
+				hi = m
+			} else {
+				lo = m + 1
+			}
+		}
+
+		for j := i; j > lo; j -= 1 {
+			s[j] = s[j-1]
+			q.traceSwap(s, j-1, j)
+		}
+		s[lo] = p
+	}
+}
@@ -0,0 +1,60 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithBinaryInsertion(t *testing.T) {
+	for n := 0; n <= 4*minLen; n += 1 {
+		for _, list := range [][]int{bits(n), sorted(n), reversed(n), pipeorgan(n), permutation(n)} {
+			want := slices.Clone(list)
+			slices.Sort(want)
+			SortFunc(list, cmp.Compare, WithBinaryInsertion())
+			if !slices.Equal(list, want) {
+				t.Fatalf("n=%d: not sorted", n)
+			}
+		}
+	}
+
+	for _, n := range []int{0, minLen, 64, 256} {
+		list := permutation(100_000)
+		SortFunc(list, cmp.Compare, WithBinaryInsertion(), WithHybridShellBase(n))
+		if !slices.IsSorted(list) {
+			t.Fatalf("shell base %d: not sorted", n)
+		}
+	}
+
+	list := records(10_000, 10)
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byKey)
+	q := newSorter(byKey, []Option{WithBinaryInsertion()})
+	q.insertion(list)
+	if !slices.Equal(list, want) {
+		t.Fatal("not stable")
+	}
+}
+
+func BenchmarkWithBinaryInsertion(b *testing.B) {
+	src := permutation(100_000)
+	list := make([]int, len(src))
+	for _, binary := range []bool{false, true} {
+		b.Run(strconv.FormatBool(binary), func(b *testing.B) {
+			var opts []Option
+			if binary {
+				opts = append(opts, WithBinaryInsertion())
+			}
+			var compares int
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortFunc(list, func(a, b int) int {
+					compares += 1
+					return cmp.Compare(a, b)
+				}, opts...)
+			}
+			b.ReportMetric(float64(compares)/float64(b.N), "compares/op")
+		})
+	}
+}
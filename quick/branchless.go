@@ -0,0 +1,90 @@
+package quick
+
+import "cmp"
+
+// WithBranchless makes SortWith use a branchless Lomuto partition.
+// Instead of branching on each comparison,
+// it swaps unconditionally, and uses the result of the comparison
+// to advance the partition boundary, or not.
+// Random data causes no mispredictions,
+// which makes this faster for basic types, like ints and floats,
+// that compare in a single instruction.
+// Other options are ignored.
+func WithBranchless() Option {
+	return func(c *config) { c.branchless = true }
+}
+
+// SortBranchless is Quicksort with a branchless Lomuto partition.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func sortBranchless[T cmp.Ordered](s []T) {
+	for len(s) > minLen {
+		r := len(s) - 1
+		if cmp.Less(s[r], s[0]) {
+			s[0], s[r] = s[r], s[0]
+		}
+		if cmp.Less(s[r/2], s[0]) {
+			s[0], s[r/2] = s[r/2], s[0]
+		}
+		if cmp.Less(s[r/2], s[r]) {
+			s[r], s[r/2] = s[r/2], s[r]
+		}
+		p := s[r]
+
+		// Now s[:i] < p, and s[i:r] ≥ p; put the pivot between them.
+		i := lomutoPartition(s[:r], p, false)
+		s[i], s[r] = s[r], s[i]
+
+		// With many duplicates, few elements may be less than the pivot.
+		// Then, gather those equal to it, which are done.
+		j := i + 1
+		b := len(s) / minRatio
+		if i < b {
+			j += lomutoPartition(s[j:], p, true)
+		}
+
+		lo, hi := s[:i], s[j:]
+		if len(lo) > len(hi) {
+			lo, hi = hi, lo
+		}
+		sortBranchless(lo)
+		// Don't insist on bad pivots.
+		if len(hi) > len(s)-b {
+			Sort(hi)
+			return
+		}
+		s = hi
+	}
+	insertion(s)
+}
+
+// LomutoPartition rearranges s so that elements less than p
+// (or not greater than p, if equal is true) come first,
+// and returns how many there are.
+// It swaps elements unconditionally, so it doesn't branch on comparisons.
+// It uses O(n) time and O(1) space.
+func lomutoPartition[T cmp.Ordered](s []T, p T, equal bool) int {
+	i := 0
+	if equal {
+		for j, v := range s {
+			s[j] = s[i]
+			s[i] = v
+			i += b2i(!cmp.Less(p, v))
+		}
+	} else {
+		for j, v := range s {
+			s[j] = s[i]
+			s[i] = v
+			i += b2i(cmp.Less(v, p))
+		}
+	}
+	return i
+}
+
+// B2i converts a bool to an int,
+// which the compiler does without branching.
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
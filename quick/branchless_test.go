@@ -0,0 +1,56 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithBranchless(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"low", cardinality(100_000, 10)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+			SortWith(tt.list, WithBranchless())
+			if !slices.Equal(tt.list, want) {
+				t.FailNow()
+			}
+		})
+	}
+
+	list := floats(100_000)
+	SortWith(list, WithBranchless())
+	if !slices.IsSorted(list) {
+		t.Fatal("floats not sorted")
+	}
+}
+
+func BenchmarkWithBranchless(b *testing.B) {
+	src := permutation(10_000_000)
+	list := make([]int, len(src))
+
+	b.Run("Hoare", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			Sort(list)
+		}
+	})
+	b.Run("Lomuto", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortWith(list, WithBranchless())
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package quick
+
+import "runtime"
+
+// WithNUMAAwareChunking makes SortFunc sort in parallel,
+// like WithParallel, but coarse grained:
+// the slice is split into one contiguous chunk per goroutine,
+// each sorted by its own goroutine, then merged.
+// Each goroutine touches a single range of memory,
+// rather than subslices scattered by partitioning,
+// which, on multi-socket machines, can cut cross-socket traffic.
+//
+// This is a heuristic: Go can't pin goroutines to threads, or threads to nodes,
+// so it can't guarantee any locality.
+// BenchmarkWithNUMAAwareChunking compares it to WithParallel;
+// only measurements on the target machine can tell if it helps.
+// Merging uses O(n) extra space.
+func WithNUMAAwareChunking() Option {
+	return func(c *config) { c.chunked = true }
+}
+
+// SortChunked sorts contiguous chunks of s concurrently,
+// then merges adjacent pairs of chunks concurrently,
+// until a single one is left.
+// It uses O(n·log(n)) time and O(n) space.
+func (q *sorter[T]) sortChunked(s []T) {
+	n := q.maxGoroutines
+	if n == 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	n = min(n, len(s)/minParallel)
+	if n < 2 {
+		q.sort(s)
+		return
+	}
+
+	// Forked sorters don't spawn goroutines of their own:
+	// the semaphore is nil.
+	par := &parallelState{profile: q.profile}
+
+	bounds := make([]int, n+1)
+	for c := range bounds {
+		bounds[c] = c * len(s) / n
+	}
+	// Forking reads the generator of q, so it's done here, not concurrently.
+	workers := make([]*sorter[T], n)
+	for c := range workers {
+		workers[c] = q.fork(par)
+	}
+	parallel(n, func(c int) {
+		w := workers[c]
+		chunk := s[bounds[c]:bounds[c+1]]
+		w.sort(chunk)
+		if w.deferBase {
+			w.insertion(chunk)
+		}
+		w.join()
+	})
+
+	for len(bounds) > 2 {
+		m := (len(bounds) - 1) / 2
+		for c := range workers[:m] {
+			workers[c] = q.fork(par)
+		}
+		parallel(m, func(c int) {
+			w := workers[c]
+			lo, mid, hi := bounds[2*c], bounds[2*c+1], bounds[2*c+2]
+			w.merge(s[lo:hi], mid-lo, nil)
+			w.join()
+		})
+		// Keep every other bound, and the last one.
+		next := bounds[:0]
+		for c := 0; c < len(bounds)-1; c += 2 {
+			next = append(next, bounds[c])
+		}
+		bounds = append(next, bounds[len(bounds)-1])
+	}
+}
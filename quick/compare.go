@@ -0,0 +1,82 @@
+package quick
+
+// FromLess converts a less function into a three-way comparison function,
+// for use with the Func variants.
+// A less function can't tell equal elements apart in a single call,
+// so the comparison function calls it twice for elements that aren't less.
+// Elements where neither is less than the other compare equal,
+// so stable sorts keep them in their original order.
+func FromLess[T any](less func(a, b T) bool) func(a, b T) int {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return +1
+		default:
+			return 0
+		}
+	}
+}
+
+// ToLess converts a three-way comparison function into a less function.
+func ToLess[T any](cmp func(a, b T) int) func(a, b T) bool {
+	return func(a, b T) bool {
+		return cmp(a, b) < 0
+	}
+}
+
+// MemoizePointers wraps a comparison function of pointers,
+// caching its results by the identity of the pair compared,
+// for use with the Func variants, to sort a slice of pointers.
+// When comparisons are costly, like traversing a graph from each node,
+// and the same pairs recur, like in repeated sorts of the same nodes,
+// with WithVerify, or through Median-of-medians, cached results save work.
+// Quicksort seldom compares a pair twice in a single sort, so this is niche.
+//
+// Each pair compared uses a map entry (about 3 words, plus map overhead),
+// which is kept until the returned function is no longer referenced,
+// so the cache grows by O(n·log(n)) entries per sort.
+// Cached results are never invalidated:
+// cmp must be pure, and the elements it compares
+// must not change while the function is in use.
+// The returned function isn't safe for concurrent use:
+// don't use it with WithParallel.
+func MemoizePointers[E any](cmp func(a, b *E) int) func(a, b *E) int {
+	type pair struct{ a, b *E }
+	cache := make(map[pair]int)
+	return func(a, b *E) int {
+		if c, ok := cache[pair{a, b}]; ok {
+			return c
+		}
+		// Results are stored as signs, so they can be negated
+		// for the swapped pair.
+		if c, ok := cache[pair{b, a}]; ok {
+			return -c
+		}
+		c := sign(cmp(a, b))
+		cache[pair{a, b}] = c
+		return c
+	}
+}
+
+// Reverse returns a comparison function that orders elements
+// in the reverse order of cmp, for use with the Func variants.
+// It swaps the arguments to cmp, rather than negate its result,
+// because negating math.MinInt overflows, leaving it negative.
+func Reverse[T any](cmp func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int { return cmp(b, a) }
+}
+
+// Sign normalizes the result of a comparison function to -1, 0, or +1,
+// so it can be safely negated.
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return +1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,122 @@
+package quick
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestFromLess(t *testing.T) {
+	c := FromLess(func(a, b int) bool { return a < b })
+	for _, a := range []int{-1, 0, 1} {
+		for _, b := range []int{-1, 0, 1} {
+			if got, want := c(a, b), cmp.Compare(a, b); got != want {
+				t.Errorf("cmp(%d, %d) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	// Round trip both conventions.
+	less := ToLess(FromLess(func(a, b int) bool { return a < b }))
+	three := FromLess(ToLess(func(a, b int) int { return 2 * cmp.Compare(a, b) }))
+	for _, a := range []int{-1, 0, 1} {
+		for _, b := range []int{-1, 0, 1} {
+			if less(a, b) != (a < b) || three(a, b) != cmp.Compare(a, b) {
+				t.Errorf("round trip of (%d, %d)", a, b)
+			}
+		}
+	}
+
+	list := records(100_000, 1000)
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byKey)
+
+	SortStableFunc(list, FromLess(func(a, b record) bool { return a.key < b.key }))
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+}
+
+func TestMemoizePointers(t *testing.T) {
+	var calls int
+	byKeyPtr := func(a, b *record) int {
+		calls += 1
+		return byKey(*a, *b)
+	}
+
+	list := records(100_000, 1000)
+	nodes := make([]*record, len(list))
+	for i := range list {
+		nodes[i] = &list[i]
+	}
+	want := slices.Clone(nodes)
+	SortFunc(want, byKeyPtr)
+
+	// The same pair, either way around, is only compared once.
+	memo := MemoizePointers(byKeyPtr)
+	calls = 0
+	a, b := nodes[0], nodes[1]
+	if memo(a, b) != -memo(b, a) || memo(a, b) != cmp.Compare(a.key, b.key) || calls != 1 {
+		t.Fatalf("got %d calls, want 1", calls)
+	}
+	if memo(a, a) != 0 {
+		t.Fatal("not reflexive")
+	}
+
+	orig := slices.Clone(nodes)
+	calls = 0
+	SortFunc(nodes, memo)
+	if !slices.Equal(nodes, want) {
+		t.Fatal("cached sort differs from uncached sort")
+	}
+	if calls == 0 {
+		t.Fatal("no calls")
+	}
+
+	// Sorting the same nodes again, every pair compared recurs.
+	copy(nodes, orig)
+	calls = 0
+	SortFunc(nodes, memo)
+	if !slices.Equal(nodes, want) {
+		t.Fatal("cached sort differs from uncached sort")
+	}
+	if calls != 0 {
+		t.Errorf("got %d calls, want 0", calls)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	// Results that overflow when negated.
+	extreme := func(a, b int) int {
+		switch {
+		case a < b:
+			return math.MinInt
+		case a > b:
+			return math.MaxInt
+		default:
+			return 0
+		}
+	}
+
+	list := permutation(100_000)
+	SortFunc(list, Reverse(extreme))
+	if !slices.IsSortedFunc(list, func(a, b int) int { return cmp.Compare(b, a) }) {
+		t.Fatal("not descending")
+	}
+
+	for _, a := range []int{-1, 0, 1} {
+		for _, b := range []int{-1, 0, 1} {
+			if got, want := sign(Reverse(extreme)(a, b)), cmp.Compare(b, a); got != want {
+				t.Errorf("Reverse(cmp)(%d, %d) = %d, want %d", a, b, got, want)
+			}
+		}
+	}
+
+	// Memoized results are negated for swapped pairs.
+	memo := MemoizePointers(func(a, b *int) int { return extreme(*a, *b) })
+	x, y := 1, 2
+	if memo(&x, &y) != -1 || memo(&y, &x) != +1 {
+		t.Error("memoized result negated wrong")
+	}
+}
@@ -0,0 +1,80 @@
+package quick
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+)
+
+const minCheck = 1024 // at least minLen
+
+// SortContext uses the Quicksort algorithm to sort a slice.
+// If ctx is done before sorting finishes,
+// it returns ctx.Err() and leaves the slice partially sorted.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortContext[T cmp.Ordered](ctx context.Context, s []T) error {
+	q := &sorter[T]{cmp: cmp.Compare[T], ctx: ctx}
+	q.sort(s)
+	return q.err
+}
+
+// SortTimeout uses the Quicksort algorithm to sort a slice.
+// If sorting takes longer than d,
+// it returns context.DeadlineExceeded and leaves the slice partially sorted.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortTimeout[T cmp.Ordered](s []T, d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return SortContext(ctx, s)
+}
+
+// SortFuncContext uses the Quicksort algorithm to sort a slice,
+// as determined by the cmp function, which is passed ctx.
+// If cmp returns an error, or ctx is done, before sorting finishes,
+// it returns that error, or ctx.Err(), and leaves the slice partially sorted.
+// After an error, cmp isn't called again.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortFuncContext[T any](ctx context.Context, s []T, cmp func(ctx context.Context, a, b T) (int, error)) error {
+	q := &sorter[T]{ctx: ctx}
+	q.cmp = func(a, b T) int {
+		// Once there's an error, pretend all elements are equal,
+		// which ends partitioning quickly.
+		if q.err != nil {
+			return 0
+		}
+		c, err := cmp(ctx, a, b)
+		if err != nil {
+			q.err = err
+		}
+		return c
+	}
+	q.sort(s)
+	return q.err
+}
+
+// SortFuncErr uses the Quicksort algorithm to sort a slice,
+// as determined by the cmp function, which may fail,
+// for instance, if it parses elements that are malformed.
+// If cmp returns an error, it returns that error, unwrapped,
+// and leaves the slice partially sorted, like SortFuncContext.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortFuncErr[T any](s []T, cmp func(a, b T) (int, error)) error {
+	return SortFuncContext(context.Background(), s, func(_ context.Context, a, b T) (int, error) {
+		return cmp(a, b)
+	})
+}
+
+// SortFuncAtomic sorts a slice like SortFuncErr,
+// but all or nothing: if cmp returns an error,
+// it restores the slice to its original order before returning the error.
+// It keeps a copy of the slice to restore, so it uses O(n) extra space.
+// It uses O(n·log(n)) time and O(n) space.
+func SortFuncAtomic[T any](s []T, cmp func(a, b T) (int, error)) error {
+	orig := slices.Clone(s)
+	err := SortFuncErr(s, cmp)
+	if err != nil {
+		copy(s, orig)
+	}
+	return err
+}
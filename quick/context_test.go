@@ -0,0 +1,160 @@
+package quick
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSortContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	list := permutation(100_000)
+	if err := SortContext(ctx, list); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+
+	// Small sorts don't check the context.
+	list = permutation(minCheck - 1)
+	if err := SortContext(ctx, list); err != nil || !slices.IsSorted(list) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestSortTimeout(t *testing.T) {
+	list := permutation(10_000_000)
+	if err := SortTimeout(list, time.Nanosecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v", err)
+	}
+
+	list = permutation(100_000)
+	if err := SortTimeout(list, time.Hour); err != nil || !slices.IsSorted(list) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestSortFuncContext(t *testing.T) {
+	ctx := context.Background()
+	compare := func(ctx context.Context, a, b int) (int, error) {
+		return cmp.Compare(a, b), nil
+	}
+
+	list := permutation(100_000)
+	if err := SortFuncContext(ctx, list, compare); err != nil || !slices.IsSorted(list) {
+		t.Fatalf("got %v", err)
+	}
+
+	errPair := errors.New("can't compare 42 and 43")
+	var calls int
+	list = permutation(100_000)
+	err := SortFuncContext(ctx, list, func(ctx context.Context, a, b int) (int, error) {
+		calls += 1
+		if min(a, b) == 42 && max(a, b) == 43 {
+			return 0, errPair
+		}
+		return cmp.Compare(a, b), nil
+	})
+	if err != errPair {
+		t.Fatalf("got %v", err)
+	}
+	if slices.IsSorted(list) {
+		t.Fatal("sorted anyway")
+	}
+	slices.Sort(list)
+	if !slices.Equal(list, sorted(len(list))) {
+		t.Fatal("lost elements")
+	}
+
+	// After the error, the comparison function isn't called again.
+	calls = 0
+	list = permutation(100_000)
+	SortFuncContext(ctx, list, func(ctx context.Context, a, b int) (int, error) {
+		calls += 1
+		if calls > 1000 {
+			t.Fatal("called after error")
+		}
+		if calls == 1000 {
+			return 0, errPair
+		}
+		return cmp.Compare(a, b), nil
+	})
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	list = permutation(100_000)
+	if err := SortFuncContext(cancelled, list, compare); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v", err)
+	}
+}
+
+func TestSortFuncErr(t *testing.T) {
+	byNumber := func(a, b string) (int, error) {
+		x, err := strconv.Atoi(a)
+		if err != nil {
+			return 0, err
+		}
+		y, err := strconv.Atoi(b)
+		if err != nil {
+			return 0, err
+		}
+		return cmp.Compare(x, y), nil
+	}
+
+	list := make([]string, 100_000)
+	for i, v := range permutation(len(list)) {
+		list[i] = strconv.Itoa(v)
+	}
+	if err := SortFuncErr(list, byNumber); err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range list {
+		if v != strconv.Itoa(i) {
+			t.Fatalf("got %s at %d", v, i)
+		}
+	}
+
+	// A single malformed element.
+	list[1234] = "12x34"
+	want := slices.Clone(list)
+	slices.Sort(want)
+	err := SortFuncErr(list, byNumber)
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) || numErr.Num != "12x34" {
+		t.Fatalf("got %v", err)
+	}
+	slices.Sort(list)
+	if !slices.Equal(list, want) {
+		t.Fatal("lost elements")
+	}
+}
+
+func TestSortFuncAtomic(t *testing.T) {
+	errPair := errors.New("can't compare 42 and 43")
+	compare := func(a, b int) (int, error) {
+		if min(a, b) == 42 && max(a, b) == 43 {
+			return 0, errPair
+		}
+		return cmp.Compare(a, b), nil
+	}
+
+	list := permutation(100_000)
+	orig := slices.Clone(list)
+	if err := SortFuncAtomic(list, compare); err != errPair {
+		t.Fatalf("got %v", err)
+	}
+	if !slices.Equal(list, orig) {
+		t.Fatal("not restored")
+	}
+
+	list = permutation(100_000)
+	list[slices.Index(list, 43)] = 44 // there's no 43 to compare to
+	if err := SortFuncAtomic(list, compare); err != nil || !slices.IsSorted(list) {
+		t.Fatalf("got %v", err)
+	}
+}
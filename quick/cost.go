@@ -0,0 +1,61 @@
+package quick
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const cmpSample = 64 // comparisons per timed one
+
+var now = time.Now // replaced by tests
+
+// WithComparatorStats makes SortFunc time a sample of the calls
+// to the comparison function, and, if they take more than threshold on average,
+// call warn with the average, once the sort finishes.
+// Comparing elements, rather than keys computed from them, can make each comparison
+// much more costly than it needs to be, like comparing long strings,
+// or formatting elements to compare them.
+// For those, SortByKey, with WithKeyCache, computes keys once per element,
+// rather than twice per comparison.
+// One in 64 comparisons is timed, so the overhead is small.
+func WithComparatorStats(threshold time.Duration, warn func(avg time.Duration)) Option {
+	return func(c *config) {
+		c.slowCmp = threshold
+		c.slowCmpWarn = warn
+	}
+}
+
+// CmpCost accumulates the time taken by the timed comparisons.
+// It's shared by all goroutines of a parallel sort.
+type cmpCost struct {
+	calls atomic.Int64
+	timed atomic.Int64
+	total atomic.Int64 // nanoseconds
+}
+
+// TimeCmp wraps the comparison function to time a sample of its calls.
+func (q *sorter[T]) timeCmp() {
+	c := &cmpCost{}
+	cmp := q.cmp
+	q.cost = c
+	q.cmp = func(a, b T) int {
+		// The first call is timed, so even tiny sorts get a sample.
+		if c.calls.Add(1)%cmpSample != 1 {
+			return cmp(a, b)
+		}
+		start := now()
+		r := cmp(a, b)
+		c.total.Add(int64(now().Sub(start)))
+		c.timed.Add(1)
+		return r
+	}
+}
+
+// Report calls warn if the average timed comparison took more than threshold.
+func (c *cmpCost) report(threshold time.Duration, warn func(avg time.Duration)) {
+	if n := c.timed.Load(); n > 0 {
+		if avg := time.Duration(c.total.Load() / n); avg > threshold {
+			warn(avg)
+		}
+	}
+}
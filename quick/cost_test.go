@@ -0,0 +1,48 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestWithComparatorStats(t *testing.T) {
+	var warned []time.Duration
+	warn := func(avg time.Duration) { warned = append(warned, avg) }
+
+	// A fake clock, which only comparison functions advance.
+	var clock time.Time
+	defer func(f func() time.Time) { now = f }(now)
+	now = func() time.Time { return clock }
+
+	// A comparison function that takes 10µs.
+	slow := func(a, b int) int {
+		clock = clock.Add(10 * time.Microsecond)
+		return cmp.Compare(a, b)
+	}
+	list := permutation(1000)
+	SortFunc(list, slow, WithComparatorStats(time.Microsecond, warn))
+	if !slices.IsSorted(list) {
+		t.FailNow()
+	}
+	if len(warned) != 1 || warned[0] != 10*time.Microsecond {
+		t.Fatalf("got warnings %v", warned)
+	}
+
+	warned = nil
+	list = permutation(100_000)
+	SortFunc(list, cmp.Compare[int], WithComparatorStats(time.Microsecond, warn))
+	if !slices.IsSorted(list) {
+		t.FailNow()
+	}
+	if len(warned) != 0 {
+		t.Fatalf("got warnings %v", warned)
+	}
+
+	// Tiny sorts get a sample.
+	SortFunc([]int{2, 1}, slow, WithComparatorStats(time.Microsecond, warn))
+	if len(warned) != 1 {
+		t.Fatalf("got warnings %v", warned)
+	}
+}
@@ -0,0 +1,36 @@
+package quick
+
+// SortCounting sorts a slice of ints in [lo, hi] with Counting sort,
+// if the range is no larger than the slice,
+// and with Sort otherwise.
+// It panics, before modifying the slice, if an element is out of range.
+// Counting sort uses O(n + hi - lo) time and O(hi - lo) space.
+func SortCounting(s []int, lo, hi int) {
+	if hi < lo {
+		panic("quick: SortCounting with hi < lo")
+	}
+	for _, v := range s {
+		if v < lo || v > hi {
+			panic("quick: SortCounting element out of range")
+		}
+	}
+
+	// This doesn't overflow, even for the whole int range.
+	r := uint(hi) - uint(lo)
+	if r >= uint(len(s)) {
+		Sort(s)
+		return
+	}
+
+	count := make([]int, r+1)
+	for _, v := range s {
+		count[v-lo] += 1
+	}
+	i := 0
+	for k, c := range count {
+		for ; c > 0; c -= 1 {
+			s[i] = lo + k
+			i += 1
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package quick
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSortCounting(t *testing.T) {
+	bounded := permutation(100_000)
+	for i := range bounded {
+		bounded[i] = bounded[i]%2000 - 1000
+	}
+
+	tests := []struct {
+		name   string
+		list   []int
+		lo, hi int
+	}{
+		{"empty", nil, 0, 0},
+		{"zeros", zeros(100_000), 0, 0},
+		{"bits", bits(100_000), 0, 1},
+		{"bounded", bounded, -1000, 999},
+		{"sorted", sorted(100_000), 0, 100_000},
+		{"wide", permutation(1000), math.MinInt, math.MaxInt},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+			SortCounting(tt.list, tt.lo, tt.hi)
+			if !slices.Equal(tt.list, want) {
+				t.FailNow()
+			}
+		})
+	}
+
+	list := []int{3, 2, 1, 7}
+	defer func() {
+		if recover() == nil || !slices.Equal(list, []int{3, 2, 1, 7}) {
+			t.Error("didn't panic before modifying the slice")
+		}
+	}()
+	SortCounting(list, 0, 5)
+}
+
+func BenchmarkSortCounting(b *testing.B) {
+	src := permutation(10_000_000)
+	for i := range src {
+		src[i] %= 1000
+	}
+	list := make([]int, len(src))
+
+	b.Run("SortCounting", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortCounting(list, 0, 999)
+		}
+	})
+	b.Run("Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			Sort(list)
+		}
+	})
+}
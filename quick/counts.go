@@ -0,0 +1,90 @@
+package quick
+
+import "cmp"
+
+// SortedCounts sorts a slice, and returns its distinct elements,
+// in ascending order, each with the number of times it occurs.
+// Sorting uses three-way partitioning,
+// which groups equal elements as soon as they're picked as pivot,
+// and stops partitioning them, so it's fast for slices with many duplicates.
+// It uses O(n·log(n)) time and O(log(n)) space, plus the result.
+func SortedCounts[T cmp.Ordered](s []T) []struct {
+	Value T
+	Count int
+} {
+	sort3(s)
+
+	var res []struct {
+		Value T
+		Count int
+	}
+	for i := 0; i < len(s); {
+		j := i + 1
+		for j < len(s) && cmp.Compare(s[i], s[j]) == 0 {
+			j += 1
+		}
+		res = append(res, struct {
+			Value T
+			Count int
+		}{s[i], j - i})
+		i = j
+	}
+	return res
+}
+
+// Sort3 is Quicksort with three-way partitioning.
+// Bad pivots are handled by falling back to Sort.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func sort3[T cmp.Ordered](s []T) {
+	for len(s) > minLen {
+		lt, gt := partition3(s)
+		lo, hi := s[:lt], s[gt:]
+		if len(lo) > len(hi) {
+			lo, hi = hi, lo
+		}
+		if b := len(s) / minRatio; len(lo) < b && len(hi) > len(s)-2*b {
+			sort3(lo)
+			Sort(hi)
+			return
+		}
+		sort3(lo)
+		s = hi
+	}
+	insertion(s)
+}
+
+// Partition3 partitions s around the median of 3 elements,
+// using Dijkstra's Dutch national flag algorithm,
+// into s[:lt] < pivot, s[lt:gt] == pivot, s[gt:] > pivot.
+// It uses cmp.Compare, so NaNs are equal to each other.
+// It uses O(n) time and O(1) space.
+func partition3[T cmp.Ordered](s []T) (lt, gt int) {
+	r := len(s) - 1
+	a, b, c := s[0], s[r/2], s[r]
+	if cmp.Less(b, a) {
+		a, b = b, a
+	}
+	if cmp.Less(c, b) {
+		b = c
+		if cmp.Less(b, a) {
+			b = a
+		}
+	}
+	p := b
+
+	lt, gt = 0, len(s)
+	for i := 0; i < gt; {
+		switch cmp.Compare(s[i], p) {
+		case -1:
+			s[lt], s[i] = s[i], s[lt]
+			lt += 1
+			i += 1
+		case +1:
+			gt -= 1
+			s[gt], s[i] = s[i], s[gt]
+		default:
+			i += 1
+		}
+	}
+	return lt, gt
+}
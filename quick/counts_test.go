@@ -0,0 +1,54 @@
+package quick
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSortedCounts(t *testing.T) {
+	list := bits(100_000)
+	var ones int
+	for _, v := range list {
+		ones += v
+	}
+	got := SortedCounts(list)
+	if len(got) != 2 ||
+		got[0].Value != 0 || got[0].Count != len(list)-ones ||
+		got[1].Value != 1 || got[1].Count != ones {
+		t.Fatalf("got %v", got)
+	}
+	if !slices.IsSorted(list) {
+		t.Fatal("not sorted")
+	}
+
+	list = permutation(100_000)
+	got = SortedCounts(list)
+	if len(got) != len(list) {
+		t.Fatalf("got %d entries", len(got))
+	}
+	for i, e := range got {
+		if e.Value != i || e.Count != 1 {
+			t.Fatalf("got %v at %d", e, i)
+		}
+	}
+
+	for _, list := range [][]int{zeros(100_000), sorted(100_000), reversed(100_000), pipeorgan(100_000), killer(128*1024 - 1)} {
+		got := SortedCounts(list)
+		if !slices.IsSorted(list) || len(got) == 0 {
+			t.Fatal("not sorted")
+		}
+	}
+
+	nan := math.NaN()
+	floats := SortedCounts([]float64{2, nan, 1, nan, 2, 2})
+	if len(floats) != 3 || !math.IsNaN(floats[0].Value) || floats[0].Count != 2 ||
+		floats[1].Value != 1 || floats[1].Count != 1 ||
+		floats[2].Value != 2 || floats[2].Count != 3 {
+		t.Fatalf("got %v", floats)
+	}
+
+	if got := SortedCounts[int](nil); len(got) != 0 {
+		t.Fatalf("got %v", got)
+	}
+}
@@ -0,0 +1,180 @@
+package quick
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/ncruces/sort/heap"
+)
+
+// SortFile sorts the records read from r, and writes them to w,
+// as determined by the cmp function, using the External merge sort algorithm:
+// it sorts chunks of up to chunk records in memory, with SortFunc,
+// spills each sorted chunk, as a run, to a temporary file,
+// then merges the runs, with heap.MergeFunc, into w.
+// Data that fits in a single chunk never touches the disk.
+//
+// decode reads the next record, returning io.EOF when there are no more;
+// encode writes a record.
+// Runs are written and read back with those, too.
+// Both get buffered readers and writers.
+//
+// Sorting chunks keeps up to chunk records in memory.
+// Merging reads a block of chunk/runs records (at least 1) of each run at a time,
+// and merges those up to the smallest of the last records read from each,
+// which are known to go before anything not yet read,
+// into an output buffer of the same size as all blocks:
+// up to 2·chunk records in memory, or 2·runs, if there are more runs than chunk.
+// Temporary files are created in os.TempDir, and removed before returning.
+// It returns the first error from decode, encode, or the file system.
+// It uses O(n·log(n)) time, O(chunk + n/chunk) space, and O(n) disk space.
+func SortFile[T any](r io.Reader, w io.Writer,
+	decode func(io.Reader) (T, error), encode func(io.Writer, T) error,
+	cmp func(a, b T) int, chunk int) (err error) {
+	chunk = max(chunk, 1)
+	br := bufio.NewReader(r)
+	bw := bufio.NewWriter(w)
+
+	var runs []*fileRun[T]
+	defer func() {
+		for _, run := range runs {
+			run.f.Close()
+			os.Remove(run.f.Name())
+		}
+	}()
+
+	write := func(w io.Writer, s []T) error {
+		for _, v := range s {
+			if err := encode(w, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	spill := func(s []T) error {
+		SortFunc(s, cmp)
+		f, err := os.CreateTemp("", "quick-sort-*")
+		if err != nil {
+			return err
+		}
+		runs = append(runs, &fileRun[T]{f: f})
+		fw := bufio.NewWriter(f)
+		if err := write(fw, s); err != nil {
+			return err
+		}
+		if err := fw.Flush(); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		runs[len(runs)-1].r = bufio.NewReader(f)
+		return nil
+	}
+
+	// Sort chunks, spilling all but the last one.
+	buf := make([]T, 0, chunk)
+	for {
+		v, err := decode(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(buf) == chunk {
+			if err := spill(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+		buf = append(buf, v)
+	}
+
+	if len(runs) == 0 {
+		SortFunc(buf, cmp)
+		if err := write(bw, buf); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+	if err := spill(buf); err != nil {
+		return err
+	}
+
+	// Merge the runs, a block of each at a time.
+	block := max(chunk/len(runs), 1)
+	for _, run := range runs {
+		run.buf = make([]T, 0, block)
+	}
+	in := make([][]T, len(runs))
+	out := make([]T, 0, block*len(runs))
+	for {
+		// Refill emptied blocks, and find the bound:
+		// later records from a run aren't less than the last one read,
+		// so records up to the smallest of those can be merged.
+		var bound T
+		bounded := false
+		for _, run := range runs {
+			if len(run.head) == 0 && !run.done {
+				if err := run.fill(decode); err != nil {
+					return err
+				}
+			}
+			if !run.done {
+				last := run.head[len(run.head)-1]
+				if !bounded || cmp(last, bound) < 0 {
+					bound = last
+					bounded = true
+				}
+			}
+		}
+
+		for i, run := range runs {
+			n := len(run.head)
+			if bounded {
+				n = searchFunc(run.head, func(v T) bool { return cmp(v, bound) > 0 })
+			}
+			in[i] = run.head[:n]
+			run.head = run.head[n:]
+		}
+		out = heap.MergeFunc(cmp, out[:0], in...)
+		if len(out) == 0 {
+			break
+		}
+		if err := write(bw, out); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// A fileRun is a sorted run spilled to a temporary file,
+// read back a block at a time.
+type fileRun[T any] struct {
+	f    *os.File
+	r    *bufio.Reader
+	buf  []T // the block
+	head []T // what's left of it
+	done bool
+}
+
+// Fill reads the next block of the run.
+func (run *fileRun[T]) fill(decode func(io.Reader) (T, error)) error {
+	run.buf = run.buf[:0]
+	for len(run.buf) < cap(run.buf) {
+		v, err := decode(run.r)
+		if err == io.EOF {
+			run.done = true
+			break
+		}
+		if err != nil {
+			return err
+		}
+		run.buf = append(run.buf, v)
+	}
+	run.head = run.buf
+	return nil
+}
@@ -0,0 +1,81 @@
+package quick
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestSortFile(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"empty", []int{}},
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, chunk := range []int{1000, 1 << 20} {
+				var in, out bytes.Buffer
+				for _, v := range tt.list {
+					encodeInt(&in, v)
+				}
+
+				err := SortFile(&in, &out, decodeInt, encodeInt, cmp.Compare[int], chunk)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var got []int
+				for {
+					v, err := decodeInt(&out)
+					if err == io.EOF {
+						break
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					got = append(got, v)
+				}
+				want := slices.Clone(tt.list)
+				slices.Sort(want)
+				if !slices.Equal(got, want) {
+					t.Fatalf("chunk=%d: not sorted", chunk)
+				}
+			}
+		})
+	}
+}
+
+func TestSortFile_error(t *testing.T) {
+	var in, out bytes.Buffer
+	for _, v := range permutation(10_000) {
+		encodeInt(&in, v)
+	}
+	in.WriteByte(0) // a truncated record
+
+	err := SortFile(&in, &out, decodeInt, encodeInt, cmp.Compare[int], 1000)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("got %v", err)
+	}
+}
+
+func encodeInt(w io.Writer, v int) error {
+	return binary.Write(w, binary.LittleEndian, int64(v))
+}
+
+func decodeInt(r io.Reader) (int, error) {
+	var v int64
+	err := binary.Read(r, binary.LittleEndian, &v)
+	return int(v), err
+}
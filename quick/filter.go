@@ -0,0 +1,153 @@
+package quick
+
+import (
+	"cmp"
+
+	"github.com/ncruces/sort/heap"
+)
+
+// MedianFilter returns the running median of s, over a sliding window,
+// without modifying s.
+// Element i of the result is the median, like MedianSmall,
+// of the window of that length centered on s[i]:
+// s[i-window/2 : i-window/2+window].
+// At the edges, the window is truncated to the elements of s it covers,
+// so the result has the same length as s.
+// Windows of up to 25 elements use MedianSmall, on a copy of each:
+// for 100,000 float64s, BenchmarkMedianFilter finds this
+// about twice as fast as the heaps for windows of 25, and 9 times for 9.
+// Larger ones keep the window in two heaps, the lower and upper halves,
+// and update them in O(log(n)) amortized time as the window slides.
+// It panics if window < 1.
+// It uses O(n·log(n)) time and O(n) space.
+func MedianFilter[T cmp.Ordered](s []T, window int) []T {
+	if window < 1 {
+		panic("quick: MedianFilter with window < 1")
+	}
+	if window <= maxMedianSmall {
+		return medianFilterSmall(s, window)
+	}
+	return medianFilterHeaps(s, window)
+}
+
+// MedianFilterSmall copies each window and finds its median with MedianSmall.
+// It uses O(n·w) time and O(w) space, plus the result.
+func medianFilterSmall[T cmp.Ordered](s []T, window int) []T {
+	res := make([]T, len(s))
+	buf := make([]T, window)
+	for i := range s {
+		lo := max(i-window/2, 0)
+		hi := min(i-window/2+window, len(s))
+		w := buf[:copy(buf, s[lo:hi])]
+		res[i] = MedianSmall(w)
+	}
+	return res
+}
+
+// MedianFilterHeaps keeps the window in two heaps:
+// a max-heap of the lower half, and a min-heap of the upper half,
+// whose smallest element is the median.
+// Elements are replaced by their unique rank in s,
+// so the max-heap can store negated ranks,
+// and elements leaving the window are marked, and lazily removed
+// once they reach the top of a heap.
+// It uses O(n·log(n)) time and O(n) space.
+func medianFilterHeaps[T cmp.Ordered](s []T, window int) []T {
+	// Ties are broken by index.
+	idx := make([]int, len(s))
+	for i := range idx {
+		idx[i] = i
+	}
+	SortFunc(idx, func(i, j int) int {
+		if c := cmp.Compare(s[i], s[j]); c != 0 {
+			return c
+		}
+		return i - j
+	})
+	rank := make([]int, len(s))
+	for r, i := range idx {
+		rank[i] = r
+	}
+
+	f := medianWindow{
+		removed: make([]bool, len(s)),
+		low:     heap.NewHeapWithCapacity[int](window),
+		high:    heap.NewHeapWithCapacity[int](window),
+	}
+	res := make([]T, len(s))
+	lo, hi := 0, 0
+	for i := range s {
+		for ; hi < min(i-window/2+window, len(s)); hi += 1 {
+			f.add(rank[hi])
+		}
+		for ; lo < i-window/2; lo += 1 {
+			f.remove(rank[lo])
+		}
+		res[i] = s[idx[f.median()]]
+	}
+	return res
+}
+
+// A medianWindow tracks the median of a window of ranks.
+// Ranks in low, negated, are all smaller than those in high,
+// and high has as many as low, or one more.
+type medianWindow struct {
+	removed   []bool
+	low, high *heap.Heap[int]
+	nlow      int // ranks in low, not removed
+	nhigh     int // ranks in high, not removed
+}
+
+func (f *medianWindow) median() int {
+	return f.high.Peek()
+}
+
+func (f *medianWindow) add(r int) {
+	if f.nhigh > 0 && r < f.high.Peek() {
+		f.low.Push(-r)
+		f.nlow += 1
+	} else {
+		f.high.Push(r)
+		f.nhigh += 1
+	}
+	f.balance()
+}
+
+func (f *medianWindow) remove(r int) {
+	f.removed[r] = true
+	if r >= f.high.Peek() {
+		f.nhigh -= 1
+	} else {
+		f.nlow -= 1
+	}
+	f.prune()
+	f.balance()
+}
+
+// Balance moves ranks between the heaps,
+// until high has as many as low, or one more.
+func (f *medianWindow) balance() {
+	for f.nlow > f.nhigh {
+		f.high.Push(-f.low.Pop())
+		f.nlow -= 1
+		f.nhigh += 1
+		f.prune()
+	}
+	for f.nhigh > f.nlow+1 {
+		f.low.Push(-f.high.Pop())
+		f.nhigh -= 1
+		f.nlow += 1
+		f.prune()
+	}
+}
+
+// Prune pops removed ranks off the top of both heaps,
+// so their tops are in the window.
+func (f *medianWindow) prune() {
+	for f.low.Len() > 0 && f.removed[-f.low.Peek()] {
+		f.low.Pop()
+	}
+	for f.high.Len() > 0 && f.removed[f.high.Peek()] {
+		f.high.Pop()
+	}
+}
@@ -0,0 +1,73 @@
+package quick
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestMedianFilter(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		for _, window := range []int{1, 2, 3, 4, 9, 25, 26, 100, 2001} {
+			list := make([]int, n)
+			for i := range list {
+				list[i] = rand.Intn(n/4 + 1)
+			}
+			orig := slices.Clone(list)
+
+			// Brute force, with Select over each window.
+			want := make([]int, n)
+			for i := range want {
+				lo := max(i-window/2, 0)
+				hi := min(i-window/2+window, n)
+				w := slices.Clone(list[lo:hi])
+				want[i] = Select(w, len(w)/2)
+			}
+
+			for name, filter := range map[string]func([]int, int) []int{
+				"MedianFilter": MedianFilter[int],
+				"small":        medianFilterSmall[int],
+				"heaps":        medianFilterHeaps[int],
+			} {
+				if window > maxMedianSmall && name == "small" {
+					continue
+				}
+				got := filter(list, window)
+				if !slices.Equal(got, want) {
+					t.Fatalf("%s(n=%d, window=%d): got %v, want %v", name, n, window, got, want)
+				}
+				if !slices.Equal(list, orig) {
+					t.Fatalf("%s(n=%d, window=%d): modified input", name, n, window)
+				}
+			}
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("didn't panic")
+			}
+		}()
+		MedianFilter([]int{1}, 0)
+	}()
+}
+
+func BenchmarkMedianFilter(b *testing.B) {
+	list := floats(100_000)
+	for _, window := range []int{9, 25, 101} {
+		if window <= maxMedianSmall {
+			b.Run("small/"+strconv.Itoa(window), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					medianFilterSmall(list, window)
+				}
+			})
+		}
+		b.Run("heaps/"+strconv.Itoa(window), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				medianFilterHeaps(list, window)
+			}
+		})
+	}
+}
@@ -0,0 +1,485 @@
+package quick
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ncruces/sort/heap"
+	"github.com/ncruces/sort/shell"
+)
+
+// SortFunc uses the Quicksort algorithm to sort a slice,
+// as determined by the cmp function.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortFunc[T any](s []T, cmp func(a, b T) int, opts ...Option) {
+	sortConfig(s, cmp, newConfig(opts))
+}
+
+// SortConfig implements SortFunc, for options that were already applied.
+func sortConfig[T any](s []T, cmp func(a, b T) int, c config) {
+	q := configSorter(cmp, c)
+	if q.deterministicOrder {
+		sortOrdered(s, q)
+		return
+	}
+	q.sortAll(s)
+}
+
+// SortLess uses the Quicksort algorithm to sort a slice,
+// as determined by the less function, like sort.Slice.
+// SortFunc is preferred: it takes a three-way comparison,
+// which tells equal elements apart in a single call.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortLess[T any](s []T, less func(a, b T) bool, opts ...Option) {
+	SortFunc(s, FromLess(less), opts...)
+}
+
+// SortFuncCounted uses the Quicksort algorithm to sort a slice,
+// as determined by the cmp function,
+// and returns the number of times it called cmp.
+// The count is deterministic: sorting the same slice again gives the same count,
+// so it can be used for accounting.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortFuncCounted[T any](s []T, cmp func(a, b T) int) uint64 {
+	var n uint64
+	SortFunc(s, func(a, b T) int {
+		n += 1
+		return cmp(a, b)
+	})
+	return n
+}
+
+// SortFirstFunc uses the Quickselect and Quicksort algorithms to sort the first k elements of a slice,
+// as determined by the cmp function.
+// It uses O(n + k·log(k)) time and O(log(n)) space.
+func SortFirstFunc[T any](s []T, k int, cmp func(a, b T) int, opts ...Option) {
+	q := newSorter(cmp, opts)
+	q.base = cap(s)
+	q.sortFirst(s, k)
+}
+
+// SelectFunc uses the Quickselect algorithm to find element k of the slice,
+// as determined by the cmp function,
+// partially sorting the slice around, and returning, s[k].
+// It uses O(n) time and O(log(n)) space.
+func SelectFunc[T any](s []T, k int, cmp func(a, b T) int, opts ...Option) T {
+	q := newSorter(cmp, opts)
+	q.base = cap(s)
+	if q.stableTies {
+		return selectStable(s, k, q)
+	}
+	return q.selectK(s, k)
+}
+
+// A sorter implements the Func variants for a given comparison function.
+// The algorithms mirror those for ordered types.
+type sorter[T any] struct {
+	cmp   func(a, b T) int
+	depth int
+	ctx   context.Context
+	err   error
+	par   *parallelState
+	trace *Tracer[T]
+	prog  *progress
+	cost  *cmpCost
+	base  int
+	// Stall makes partition report bad pivots in stalled,
+	// rather than replace them.
+	stall   bool
+	stalled bool
+	config
+}
+
+func newSorter[T any](cmp func(a, b T) int, opts []Option) *sorter[T] {
+	return configSorter(cmp, newConfig(opts))
+}
+
+// ConfigSorter is like newSorter, for options that were already applied.
+func configSorter[T any](cmp func(a, b T) int, c config) *sorter[T] {
+	q := &sorter[T]{cmp: cmp, config: c}
+	if q.descending {
+		q.cmp = func(a, b T) int { return cmp(b, a) }
+	}
+	if t, ok := q.tracer.(Tracer[T]); ok {
+		q.trace = &t
+	}
+	if q.slowCmpWarn != nil {
+		q.timeCmp()
+	}
+	return q
+}
+
+// SortAll sorts all of s, as configured: it implements SortFunc.
+func (q *sorter[T]) sortAll(s []T) {
+	q.base = cap(s)
+	if q.validateDirection {
+		q.checkDirection(s)
+	}
+	if q.progress != nil {
+		q.prog = newProgress(q.progress, len(s))
+	}
+	switch {
+	case q.equalRange != nil:
+		q.sort3(s)
+	case q.stdlib && len(s) <= maxStdlib:
+		slices.SortFunc(s, q.cmp)
+	case q.reverseInput && q.sortReversed(s):
+	case q.runDetection && q.sortRuns(s):
+	case q.indirect:
+		q.sortIndirect(s)
+	case q.chunked:
+		q.sortChunked(s)
+	case q.parallel:
+		q.sortParallel(s)
+	case q.adaptive && q.fewRuns(s, len(s)/minRunLen) && q.sortRuns(s):
+	case q.adaptive && q.manyDuplicates(s):
+		q.sort3(s)
+	default:
+		q.sort(s)
+	}
+	if q.deferBase {
+		q.insertion(s)
+	}
+	q.finish()
+	if q.cost != nil {
+		q.cost.report(q.slowCmp, q.slowCmpWarn)
+	}
+	if q.verify {
+		q.verifySorted(s)
+	}
+}
+
+func (q *sorter[T]) less(a, b T) bool {
+	if p := q.profile; p != nil {
+		p.Comparisons += 1
+	}
+	return q.cmp(a, b) < 0
+}
+
+func (q *sorter[T]) sort(s []T) {
+	q.depth += 1
+	if p := q.profile; p != nil && p.MaxDepth < q.depth {
+		p.MaxDepth = q.depth
+	}
+	if q.maxDepth > 0 && q.depth > q.maxDepth {
+		heap.SortFunc(s, q.cmp)
+		q.placed(len(s))
+		q.depth -= 1
+		return
+	}
+
+	for len(s) > max(q.insertionLen(), q.shellBase) && !q.stopped(len(s)) {
+		if q.minWrites && q.isSorted(s) {
+			q.placed(len(s))
+			s = s[:0]
+			break
+		}
+		p := q.partition(s)
+		if p > len(s)/2 && !q.leftFirst {
+			q.spawn(s[p:])
+			s = s[:p]
+		} else {
+			q.spawn(s[:p])
+			s = s[p:]
+		}
+	}
+	switch {
+	case len(s) <= q.insertionLen():
+		if !q.deferBase {
+			q.insertion(s)
+		}
+		q.placed(len(s))
+	case len(s) <= q.shellBase:
+		shell.SortFunc(s, q.cmp)
+		q.placed(len(s))
+	}
+	q.depth -= 1
+}
+
+// Stopped checks if sorting should stop early.
+// The context is only checked before partitioning large slices,
+// so small sorts aren't slowed down by it.
+func (q *sorter[T]) stopped(n int) bool {
+	if q.err == nil && q.ctx != nil && n >= minCheck {
+		q.err = q.ctx.Err()
+	}
+	return q.err != nil
+}
+
+func (q *sorter[T]) sortFirst(s []T, k int) {
+	_ = s[:k]
+	if q.deferBase {
+		// Sorting s[:p] leaves small subslices for a final pass.
+		defer q.insertion(s[:k])
+	}
+
+	for k > q.selectionK() {
+		p := q.partition(s)
+		if p > k {
+			s = s[:p]
+		} else {
+			q.sort(s[:p])
+			s = s[p:]
+			k -= p
+		}
+	}
+	q.selection(s, k)
+}
+
+func (q *sorter[T]) selectK(s []T, k int) T {
+	_ = s[k]
+
+	// Introselect: past a budget of work, select pivots deterministically.
+	// This is local to this selection, not nested or later ones.
+	work := 0
+	budget := maxWork * len(s)
+	deterministic := false
+
+	report := q.selectProgress
+	if report != nil {
+		// Nested selections, like for Median-of-medians, don't report.
+		q.selectProgress = nil
+		defer func() {
+			q.selectProgress = report
+			report(0)
+		}()
+	}
+
+	// Only this selection, not the sort it may be nested in, stalls.
+	stall := q.stall
+	q.stall = q.heapFallback
+	defer func() {
+		q.stall = stall
+		q.stalled = false
+	}()
+
+	for k >= q.selectionK() {
+		if report != nil {
+			report(len(s))
+		}
+		if q.linear && !deterministic {
+			work += len(s)
+			deterministic = work > budget
+		}
+		var p int
+		if deterministic {
+			p = q.partitionMedMed(s)
+		} else {
+			p = q.partition(s)
+		}
+		if q.stalled {
+			q.heapSelect(s, k)
+			return s[k]
+		}
+		if p > k {
+			s = s[:p]
+		} else {
+			s = s[p:]
+			k -= p
+		}
+	}
+	q.selection(s, k+1)
+	return s[k]
+}
+
+func (q *sorter[T]) partition(s []T) int {
+	if q.deterministic {
+		return q.partitionMedMed(s)
+	}
+
+	r := len(s) - 1
+	if p := q.profile; p != nil {
+		p.Partitions += 1
+	}
+
+	switch {
+	case q.rand != nil:
+		q.swap(s, q.rand.Intn(len(s)), r/2)
+	case r >= minMed3:
+		if q.lessAt(s, s[r], s[0], r, 0) {
+			q.swap(s, 0, r)
+		}
+		if q.lessAt(s, s[r/2], s[0], r/2, 0) {
+			q.swap(s, 0, r/2)
+		}
+		if q.lessAt(s, s[r], s[r/2], r, r/2) {
+			q.swap(s, r, r/2)
+		}
+	}
+
+	p := s[r/2]
+	i := q.hoarePartition(s, p)
+
+	if r >= minMedMed {
+		b := r / minRatio
+		if !(b < i && i < r-b) {
+			if p := q.profile; p != nil {
+				p.Fallbacks += 1
+			}
+			if q.stall {
+				q.stalled = true
+				return i
+			}
+			p = q.medianOfMedians(s)
+			i = q.hoarePartition(s, p)
+		}
+	}
+	q.tracePivot(s, p, i)
+	return i
+}
+
+// PartitionMedMed partitions s around its Median-of-medians.
+func (q *sorter[T]) partitionMedMed(s []T) int {
+	if p := q.profile; p != nil {
+		p.Partitions += 1
+	}
+	p := q.medianOfMedians(s)
+	i := q.hoarePartition(s, p)
+	q.tracePivot(s, p, i)
+	return i
+}
+
+// HoarePartition, Insertion and Selection use q.less directly;
+// traced sorts use the traced variants, which report each comparison.
+func (q *sorter[T]) hoarePartition(s []T, p T) int {
+	switch {
+	case q.prefetch:
+		return q.hoarePartitionPrefetch(s, p)
+	case q.trace != nil:
+		return q.hoarePartitionTraced(s, p)
+	}
+	r := len(s) - 1
+	i := 0
+	j := r
+	for {
+		for i < r && q.less(s[i], p) {
+			i += 1
+		}
+		for j > 0 && q.less(p, s[j]) {
+			j -= 1
+		}
+		if i > j {
+			return i
+		}
+		if i < j {
+			s[i], s[j] = s[j], s[i]
+			q.countSwaps(1)
+		}
+		i += 1
+		j -= 1
+	}
+}
+
+func (q *sorter[T]) insertion(s []T) {
+	switch {
+	case q.binaryInsertion:
+		q.binaryInsertionSort(s)
+		return
+	case q.trace != nil:
+		q.insertionTraced(s)
+		return
+	}
+	for i, p := range s {
+		j := i
+		for j > 0 && q.less(p, s[j-1]) {
+			s[j] = s[j-1]
+			j -= 1
+		}
+		// Elements already in place aren't written back.
+		if j != i {
+			s[j] = p
+			q.countSwaps(i - j)
+		}
+	}
+}
+
+func (q *sorter[T]) selection(s []T, k int) {
+	if q.trace != nil {
+		q.selectionTraced(s, k)
+		return
+	}
+	for i, p := range s[:k] {
+		m := 0
+		for j, v := range s[i:] {
+			if q.less(v, p) {
+				m = j
+				p = v
+			}
+		}
+		if m != 0 {
+			s[i], s[m+i] = s[m+i], s[i]
+			q.countSwaps(1)
+		}
+	}
+}
+
+func (q *sorter[T]) medianOfMedians(s []T) T {
+	m := 0
+	for i := 0; i+5 < len(s); i += 5 {
+		q.insertion(s[i : i+5])
+		if m != i+2 {
+			q.swap(s, m, i+2)
+		}
+		m += 1
+	}
+	if m < 2 {
+		return s[0]
+	}
+	return q.selectK(s[:m], m/2)
+}
+
+// An indexed element remembers its original position,
+// which is used to break ties.
+type indexed[T any] struct {
+	v T
+	i int
+}
+
+// Tag pairs each element of s with its index.
+func tag[T any](s []T) []indexed[T] {
+	x := make([]indexed[T], len(s))
+	for i, v := range s {
+		x[i] = indexed[T]{v, i}
+	}
+	return x
+}
+
+// Untag copies the elements of x back into s.
+func untag[T any](s []T, x []indexed[T]) {
+	for i, e := range x {
+		s[i] = e.v
+	}
+}
+
+// ByIndex breaks ties in cmp by original index.
+func byIndex[T any](cmp func(a, b T) int) func(a, b indexed[T]) int {
+	return func(a, b indexed[T]) int {
+		if c := cmp(a.v, b.v); c != 0 {
+			return c
+		}
+		return a.i - b.i
+	}
+}
+
+// SelectStable selects over elements tagged with their original index,
+// then moves the earliest of the elements equal to s[k] into place.
+// It uses O(n) time and O(n) space.
+func selectStable[T any](s []T, k int, q *sorter[T]) T {
+	_ = s[k]
+
+	x := tag(s)
+	t := &sorter[indexed[T]]{cmp: byIndex(q.cmp), config: q.config}
+	t.selectK(x, k)
+
+	// Equal elements with a smaller index can only be to the left of k.
+	m := k
+	for j, e := range x[:k] {
+		if q.cmp(e.v, x[k].v) == 0 && e.i < x[m].i {
+			m = j
+		}
+	}
+	x[k], x[m] = x[m], x[k]
+
+	untag(s, x)
+	return s[k]
+}
@@ -0,0 +1,145 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestSortFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortFunc(tt.list, cmp.Compare)
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestSortLess(t *testing.T) {
+	list := records(100_000, 1000)
+	want := slices.Clone(list)
+
+	// Both conventions share the same core,
+	// so even the order of equal elements matches.
+	SortFunc(want, byKey)
+	SortLess(list, func(a, b record) bool { return a.key < b.key })
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+}
+
+func TestSortFuncCounted(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"empty", nil},
+		{"zeros", zeros(100_000)},
+		{"sorted", sorted(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tally uint64
+			list := slices.Clone(tt.list)
+			SortFunc(list, func(a, b int) int {
+				tally += 1
+				return cmp.Compare(a, b)
+			})
+
+			n := SortFuncCounted(tt.list, cmp.Compare)
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			if n != tally {
+				t.Fatalf("got %d, want %d", n, tally)
+			}
+		})
+	}
+}
+
+func TestSortFirstFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortFirstFunc(tt.list, 1111, cmp.Compare)
+			if !slices.IsSorted(tt.list[:1111]) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestSelectFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := SelectFunc(tt.list, 1111, cmp.Compare)
+			slices.Sort(tt.list)
+			if sel != tt.list[1111] {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestSelectFunc_stableTies(t *testing.T) {
+	list := make([]record, 100_000)
+	for i, k := range bits(len(list)) {
+		list[i] = record{k * (i % 7), i}
+	}
+
+	for _, k := range []int{0, 3, 1111, 50_000, len(list) - 1} {
+		s := slices.Clone(list)
+		sel := SelectFunc(s, k, byKey, WithStableTies())
+
+		ref := slices.Clone(list)
+		slices.SortStableFunc(ref, byKey)
+		if sel.key != ref[k].key {
+			t.Fatalf("k=%d: got key %d, want %d", k, sel.key, ref[k].key)
+		}
+		first := slices.IndexFunc(ref, func(r record) bool { return r.key == sel.key })
+		if sel.id != ref[first].id {
+			t.Fatalf("k=%d: got id %d, want %d", k, sel.id, ref[first].id)
+		}
+		if s[k] != sel {
+			t.Fatalf("k=%d: s[k] != result", k)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package quick
+
+// HeapSelect moves element k of s into place, with a bounded binary heap,
+// of the k+1 smallest, or the n-k largest, elements, whichever is fewer.
+// It uses O(n·log(min(k, n-k))) time and O(1) space.
+func (q *sorter[T]) heapSelect(s []T, k int) {
+	if k < len(s)/2 {
+		// A max-heap of the k+1 smallest: its root is element k.
+		h := s[:k+1]
+		boundedHeap(h, s[k+1:], q.less)
+		s[0], s[k] = s[k], s[0]
+	} else {
+		// A min-heap of the n-k largest, at s[k:]: its root is element k.
+		boundedHeap(s[k:], s[:k], func(a, b T) bool { return q.less(b, a) })
+	}
+}
+
+// BoundedHeap rearranges h into a binary heap, ordered by less,
+// with its greatest element at the root,
+// then swaps each element of rest lesser than the root with it.
+// Afterwards, h holds the least elements of both,
+// and rest those not less than the root.
+// It uses O(n·log(k)) time and O(1) space, for k elements in h.
+func boundedHeap[T any](h, rest []T, less func(a, b T) bool) {
+	for i := len(h)/2 - 1; i >= 0; i -= 1 {
+		siftDownLess(h, i, less)
+	}
+	for i := range rest {
+		if less(rest[i], h[0]) {
+			h[0], rest[i] = rest[i], h[0]
+			siftDownLess(h, 0, less)
+		}
+	}
+}
+
+// SiftDownLess moves element i down the heap,
+// until its children aren't greater.
+// It uses O(log(n)) time and O(1) space.
+func siftDownLess[T any](s []T, i int, less func(a, b T) bool) {
+	for {
+		l := 2*i + 1
+		if l >= len(s) {
+			return
+		}
+		if r := l + 1; r < len(s) && less(s[l], s[r]) {
+			l = r
+		}
+		if !less(s[i], s[l]) {
+			return
+		}
+		s[i], s[l] = s[l], s[i]
+		i = l
+	}
+}
@@ -0,0 +1,80 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithHeapFallback(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			for _, k := range []int{0, 5, 1111, len(want) / 2, len(want) - 1111, len(want) - 1} {
+				var p Profile
+				list := slices.Clone(tt.list)
+				sel := SelectWith(list, k, WithHeapFallback(), WithProfile(&p))
+				if sel != want[k] || list[k] != sel {
+					t.Fatalf("k=%d: got %d, want %d", k, sel, want[k])
+				}
+				if slices.Max(list[:k+1]) != sel || slices.Min(list[k:]) != sel {
+					t.Fatalf("k=%d: not partitioned around k", k)
+				}
+				if tt.name == "killer" && k > minK && p.Fallbacks == 0 {
+					t.Errorf("k=%d: fallback didn't trigger", k)
+				}
+			}
+		})
+	}
+
+	// Selections nested in a sort, for Median-of-medians,
+	// don't keep the rest of it from replacing bad pivots.
+	var with, without Profile
+	SortFunc(killer(128*1024-1), cmp.Compare, WithProfile(&without))
+	q := newSorter(cmp.Compare[int], []Option{WithHeapFallback(), WithProfile(&with)})
+	list := killer(128*1024 - 1)
+	q.sortAll(list)
+	if !slices.IsSorted(list) {
+		t.Fatal("not sorted")
+	}
+	if q.stall || q.stalled {
+		t.Error("sort left stalling")
+	}
+	if with.Comparisons > without.Comparisons*5/4 {
+		t.Errorf("got %d comparisons, want about %d", with.Comparisons, without.Comparisons)
+	}
+}
+
+func BenchmarkWithHeapFallback(b *testing.B) {
+	src := killer(1024*1024 - 1)
+	list := make([]int, len(src))
+	for _, k := range []int{100, 10_000, len(src) / 2} {
+		for _, heap := range []bool{false, true} {
+			b.Run(strconv.Itoa(k)+"/"+strconv.FormatBool(heap), func(b *testing.B) {
+				var opts []Option
+				if heap {
+					opts = append(opts, WithHeapFallback())
+				}
+				for i := 0; i < b.N; i++ {
+					copy(list, src)
+					SelectWith(list, k, opts...)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package quick
+
+const maxShifts = 8 // times n
+
+// SortIndex returns the permutation that sorts s, leaving s unchanged:
+// s[x[0]], s[x[1]], … are in order, for the returned x.
+// Sorting indices needs as many comparisons as sorting elements,
+// and those are counted in the statistics.
+// It uses O(n·log(n)) time and O(n) space.
+func (x *Sorter[T]) SortIndex(s []T) []int {
+	idx := make([]int, len(s))
+	for i := range idx {
+		idx[i] = i
+	}
+
+	var p Profile
+	c := x.indexConfig(&p)
+	sortConfig(idx, x.indexCmp(s), c)
+	x.record(p, len(s))
+	return idx
+}
+
+// SortIndexReusing is like SortIndex,
+// but starts from prev, the permutation that sorted s before it changed,
+// sorting prev in place, and returning it.
+// If s changed little, prev is nearly sorted for it,
+// and Insertion sort fixes it in O(n + d) time, for d displaced pairs of elements.
+// If that takes too long, the rest is sorted as usual, in O(n·log(n)) time.
+// For values that drift by a tiny fraction of their spread,
+// BenchmarkSorter_SortIndexReusing finds 25 times fewer comparisons than SortIndex.
+// If prev isn't of the same length as s, it's a cold start, like SortIndex.
+// Otherwise, prev must be a permutation of the indices of s.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func (x *Sorter[T]) SortIndexReusing(prev []int, s []T) []int {
+	if len(prev) != len(s) {
+		return x.SortIndex(s)
+	}
+
+	var p Profile
+	c := x.indexConfig(&p)
+	q := configSorter(x.indexCmp(s), c)
+	if !q.insertionBounded(prev, maxShifts*len(prev)) {
+		q.sortAll(prev)
+	}
+	x.record(p, len(s))
+	return prev
+}
+
+// IndexConfig is the configuration for sorting indices, profiled by p.
+func (x *Sorter[T]) indexConfig(p *Profile) config {
+	c := x.sortConfig(p)
+	// A Tracer follows elements, not indices.
+	c.tracer = nil
+	return c
+}
+
+// IndexCmp compares indices by the elements of s they point to.
+func (x *Sorter[T]) indexCmp(s []T) func(i, j int) int {
+	return func(i, j int) int { return x.cmp(s[i], s[j]) }
+}
+
+// InsertionBounded is Insertion sort, that gives up after limit shifts,
+// leaving s partially sorted, but still holding the same elements.
+// It reports whether it sorted s.
+// It uses O(n + limit) time and O(1) space.
+func (q *sorter[T]) insertionBounded(s []T, limit int) bool {
+	for i, p := range s {
+		j := i
+		for j > 0 && q.less(p, s[j-1]) {
+			s[j] = s[j-1]
+			j -= 1
+		}
+		if j != i {
+			s[j] = p
+			limit -= i - j
+			if pr := q.profile; pr != nil {
+				pr.Swaps += i - j
+			}
+			if limit < 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package quick
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSorter_SortIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"empty", nil},
+		{"zeros", zeros(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			x := NewSorter(cmp.Compare[int])
+			idx := x.SortIndex(tt.list)
+			if !slices.Equal(tt.list, want) {
+				t.Fatal("modified")
+			}
+			checkIndex(t, tt.list, idx)
+			if got := x.Stats(); got.Sorts != 1 || got.Elements != len(tt.list) {
+				t.Errorf("got %+v", got)
+			}
+		})
+	}
+}
+
+func TestSorter_SortIndexReusing(t *testing.T) {
+	x := NewSorter(cmp.Compare[float64])
+
+	// Cold start.
+	list := drifting(100_000)
+	idx := x.SortIndexReusing(nil, list)
+	checkIndex(t, list, idx)
+
+	// Slowly changing data.
+	for i := 0; i < 10; i += 1 {
+		drift(list, 0.01)
+		before := x.Stats()
+		idx = x.SortIndexReusing(idx, list)
+		checkIndex(t, list, idx)
+		if n := x.Stats().Comparisons - before.Comparisons; n > 3*len(list) {
+			t.Errorf("tick %d: %d comparisons", i, n)
+		}
+	}
+
+	// Completely changed data.
+	rand.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+	idx = x.SortIndexReusing(idx, list)
+	checkIndex(t, list, idx)
+
+	// Changed length.
+	idx = x.SortIndexReusing(idx, list[:1000])
+	checkIndex(t, list[:1000], idx)
+}
+
+func BenchmarkSorter_SortIndexReusing(b *testing.B) {
+	src := drifting(1_000_000)
+	x := NewSorter(cmp.Compare[float64])
+	idx := x.SortIndex(src)
+
+	b.Run("cold", func(b *testing.B) {
+		list := slices.Clone(src)
+		var n int
+		for i := 0; i < b.N; i++ {
+			drift(list, 0.01)
+			before := x.Stats()
+			x.SortIndex(list)
+			n += x.Stats().Comparisons - before.Comparisons
+		}
+		b.ReportMetric(float64(n)/float64(b.N), "cmps/op")
+	})
+	b.Run("warm", func(b *testing.B) {
+		list := slices.Clone(src)
+		idx := slices.Clone(idx)
+		var n int
+		for i := 0; i < b.N; i++ {
+			drift(list, 0.01)
+			before := x.Stats()
+			idx = x.SortIndexReusing(idx, list)
+			n += x.Stats().Comparisons - before.Comparisons
+		}
+		b.ReportMetric(float64(n)/float64(b.N), "cmps/op")
+	})
+}
+
+// CheckIndex checks that idx is a permutation that sorts s.
+func checkIndex[T cmp.Ordered](t testing.TB, s []T, idx []int) {
+	t.Helper()
+	if len(idx) != len(s) {
+		t.Fatalf("got %d indices, want %d", len(idx), len(s))
+	}
+	seen := make([]bool, len(s))
+	for i, j := range idx {
+		if seen[j] {
+			t.Fatalf("index %d repeated", j)
+		}
+		seen[j] = true
+		if i > 0 && cmp.Less(s[j], s[idx[i-1]]) {
+			t.Fatalf("not sorted at %d", i)
+		}
+	}
+}
+
+// Drifting returns n values spread over [0, n).
+func drifting(n int) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = rand.Float64() * float64(n)
+	}
+	return s
+}
+
+// Drift moves each value of s by up to ±d.
+func drift(s []float64, d float64) {
+	for i := range s {
+		s[i] += (2*rand.Float64() - 1) * d
+	}
+}
@@ -0,0 +1,42 @@
+package quick
+
+// SortIndirect sorts the indices of the elements of s,
+// then moves each element into place, once.
+// For large elements, this beats moving them on every swap.
+// It uses O(n·log(n)) time and O(n) space.
+func (q *sorter[T]) sortIndirect(s []T) {
+	x := q.ints(len(s))
+	for i := range x {
+		x[i] = i
+	}
+
+	t := &sorter[int]{config: q.config}
+	t.cmp = func(i, j int) int { return q.cmp(s[i], s[j]) }
+	t.sort(x)
+
+	permute(s, x)
+}
+
+// Permute rearranges s so that s[i] becomes the old s[x[i]],
+// by following the cycles of the permutation, which it destroys.
+// Each element is moved once, plus one extra move per cycle.
+// It uses O(n) time and O(1) space.
+func permute[T any](s []T, x []int) {
+	for i := range x {
+		if x[i] == i {
+			continue
+		}
+		t := s[i]
+		j := i
+		for {
+			k := x[j]
+			x[j] = j
+			if k == i {
+				s[j] = t
+				break
+			}
+			s[j] = s[k]
+			j = k
+		}
+	}
+}
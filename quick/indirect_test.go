@@ -0,0 +1,88 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// A large element, 256 bytes in size.
+type large struct {
+	key float64
+	pad [31]float64
+}
+
+func larges(n int) []large {
+	s := make([]large, n)
+	for i, f := range floats(n) {
+		s[i].key = f
+		s[i].pad[0] = f
+	}
+	return s
+}
+
+func byLargeKey(a, b large) int { return cmp.Compare(a.key, b.key) }
+
+func TestWithIndirectSort(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortWith(tt.list, WithIndirectSort())
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+
+	t.Run("large", func(t *testing.T) {
+		list := larges(100_000)
+		SortFunc(list, byLargeKey, WithIndirectSort())
+		if !slices.IsSortedFunc(list, byLargeKey) {
+			t.FailNow()
+		}
+		for _, e := range list {
+			if e.key != e.pad[0] {
+				t.FailNow()
+			}
+		}
+	})
+}
+
+func TestPermute(t *testing.T) {
+	s := permutation(1000)
+	x := make([]int, len(s))
+	for i, v := range s {
+		x[v] = i
+	}
+	permute(s, x)
+	if !slices.Equal(s, sorted(1000)) {
+		t.FailNow()
+	}
+}
+
+func BenchmarkSortFunc_large(b *testing.B) {
+	src := larges(100_000)
+	list := make([]large, len(src))
+	for i := 0; i < b.N; i++ {
+		copy(list, src)
+		SortFunc(list, byLargeKey)
+	}
+}
+
+func BenchmarkSortFunc_largeIndirect(b *testing.B) {
+	src := larges(100_000)
+	list := make([]large, len(src))
+	for i := 0; i < b.N; i++ {
+		copy(list, src)
+		SortFunc(list, byLargeKey, WithIndirectSort())
+	}
+}
@@ -0,0 +1,34 @@
+package quick
+
+import "cmp"
+
+// SortIterative uses the Quicksort algorithm to sort a slice,
+// without recursion: pending subslices are kept on an explicit stack.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortIterative[T cmp.Ordered](s []T) {
+	// Pushing the larger side, and looping on the smaller one,
+	// means the stack holds at most log₂(n) < 64 subslices.
+	var stack [64][]T
+	var n int
+
+	for {
+		for len(s) > minLen {
+			p := partition(s)
+			if p > len(s)/2 {
+				stack[n] = s[:p]
+				s = s[p:]
+			} else {
+				stack[n] = s[p:]
+				s = s[:p]
+			}
+			n += 1
+		}
+		insertion(s)
+
+		if n == 0 {
+			return
+		}
+		n -= 1
+		s = stack[n]
+	}
+}
@@ -0,0 +1,35 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortIterative(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortIterative(tt.list)
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func BenchmarkSortIterative(b *testing.B) {
+	list := floats(10_000_000)
+	b.ResetTimer()
+	SortIterative(list)
+}
@@ -0,0 +1,177 @@
+package quick
+
+import (
+	"cmp"
+	"encoding/binary"
+	"math"
+)
+
+// SortPtr uses the Quicksort algorithm to sort a slice of pointers,
+// by the key of the values they point to.
+// Nil pointers are sorted to the end of the slice.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortPtr[T any, K cmp.Ordered](s []*T, key func(*T) K) {
+	SortFunc(s, func(a, b *T) int {
+		switch {
+		case a == nil || b == nil:
+			return boolCompare(a == nil, b == nil)
+		default:
+			return cmp.Compare(key(a), key(b))
+		}
+	})
+}
+
+// SortByKey uses the Quicksort algorithm to sort a slice,
+// by the key of each element, configured by opts.
+// Keys are computed on every comparison, unless WithKeyCache is used.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortByKey[T any, K cmp.Ordered](s []T, key func(T) K, opts ...Option) {
+	c := newConfig(opts)
+	if !c.keyCache {
+		configSorter(func(a, b T) int {
+			return cmp.Compare(key(a), key(b))
+		}, c).sortAll(s)
+		return
+	}
+
+	// Compute each key once, sort indices by key,
+	// then move each element into place.
+	keys := make([]K, len(s))
+	for i, v := range s {
+		keys[i] = key(v)
+	}
+	x := c.ints(len(s))
+	for i := range x {
+		x[i] = i
+	}
+	configSorter(func(i, j int) int {
+		return cmp.Compare(keys[i], keys[j])
+	}, c).sortAll(x)
+	permute(s, x)
+}
+
+// SortByIntKey sorts a slice by the int key of each element,
+// keeping elements with equal keys in their original order.
+// Keys are computed once, and sorted with their indices by LSD Radix sort,
+// which skips the bytes that are the same for all keys;
+// then each element is moved into place.
+// It uses O(n) time and O(n) space.
+func SortByIntKey[T any](s []T, key func(T) int) {
+	// Flipping the sign bit orders negative keys before positive ones.
+	x := make([]keyed, len(s))
+	for i, v := range s {
+		x[i] = keyed{uint64(key(v)) ^ 1<<63, i}
+	}
+	sortKeyed(s, x)
+}
+
+// SortFloat64Keys sorts a slice by the float64 key of each element,
+// keeping elements with equal keys in their original order.
+// Elements with NaN keys are sorted to the end of the slice,
+// and zeros of either sign are equal.
+// Keys are computed once, and sorted like SortByIntKey,
+// in tight loops over their bits, rather than with comparisons.
+// It uses O(n) time and O(n) space.
+func SortFloat64Keys[T any](s []T, key func(T) float64) {
+	x := make([]keyed, len(s))
+	for i, v := range s {
+		x[i] = keyed{floatBits(key(v)), i}
+	}
+	sortKeyed(s, x)
+}
+
+// SortStableByBytes sorts a slice by the 8 byte key of each element,
+// compared byte by byte, like bytes.Compare,
+// keeping elements with equal keys in their original order.
+// This suits keys that are encoded to sort, like big-endian timestamps or IDs.
+// Keys are computed once, and sorted like SortByIntKey,
+// in tight loops over their bytes, rather than with comparisons.
+// It uses O(n) time and O(n) space.
+func SortStableByBytes[T any](s []T, key func(T) [8]byte) {
+	x := make([]keyed, len(s))
+	for i, v := range s {
+		k := key(v)
+		x[i] = keyed{binary.BigEndian.Uint64(k[:]), i}
+	}
+	sortKeyed(s, x)
+}
+
+// FloatBits maps a float64 to an uint64 with the same order.
+// Negative floats have their bits flipped,
+// so larger magnitudes come first,
+// positive floats have their sign bit set,
+// NaNs come last.
+func floatBits(f float64) uint64 {
+	switch {
+	case f != f:
+		return math.MaxUint64
+	case f == 0:
+		f = 0 // drops the sign of -0
+	}
+	b := math.Float64bits(f)
+	if b>>63 != 0 {
+		return ^b
+	}
+	return b | 1<<63
+}
+
+// SortKeyed sorts x by key with LSD Radix sort,
+// then moves the elements of s into the order of the sorted indices.
+// It uses O(n) time and O(n) space.
+func sortKeyed[T any](s []T, x []keyed) {
+	buf := make([]keyed, len(x))
+	for shift := 0; shift < 64 && len(x) > 0; shift += 8 {
+		var count [256]int
+		for _, e := range x {
+			count[byte(e.k>>shift)] += 1
+		}
+		if count[byte(x[0].k>>shift)] == len(x) {
+			continue
+		}
+
+		var off int
+		for b, c := range count {
+			count[b] = off
+			off += c
+		}
+		for _, e := range x {
+			b := byte(e.k >> shift)
+			buf[count[b]] = e
+			count[b] += 1
+		}
+		x, buf = buf, x
+	}
+
+	idx := make([]int, len(s))
+	for i, e := range x {
+		idx[i] = e.i
+	}
+	permute(s, idx)
+}
+
+// A keyed index pairs an element index with its key.
+type keyed struct {
+	k uint64
+	i int
+}
+
+// WithKeyCache makes SortByKey compute the key of each element once,
+// rather than on every comparison:
+// it's the Schwartzian transform.
+// This pays off for expensive key functions.
+// It uses O(n) extra space.
+func WithKeyCache() Option {
+	return func(c *config) { c.keyCache = true }
+}
+
+// BoolCompare orders false before true.
+func boolCompare(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return +1
+	default:
+		return -1
+	}
+}
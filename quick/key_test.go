@@ -0,0 +1,187 @@
+package quick
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSortPtr(t *testing.T) {
+	var list []*int
+	for i, v := range permutation(100_000) {
+		if i%100 == 0 {
+			list = append(list, nil)
+		}
+		v := v
+		list = append(list, &v)
+	}
+
+	SortPtr(list, func(p *int) int { return *p })
+
+	n := slices.Index(list, nil)
+	if n != 100_000 {
+		t.Fatalf("got first nil at %d", n)
+	}
+	for i, p := range list {
+		if i < n && *p != i || i >= n && p != nil {
+			t.Fatalf("wrong element at %d", i)
+		}
+	}
+}
+
+func TestSortByKey(t *testing.T) {
+	list := records(100_000, 1000)
+	key := func(r record) int { return r.key }
+
+	want := slices.Clone(list)
+	SortByKey(want, key)
+	if !slices.IsSortedFunc(want, byKey) {
+		t.Fatal("not sorted")
+	}
+
+	var calls int
+	SortByKey(list, func(r record) int {
+		calls += 1
+		if calls > len(list) {
+			panic("key computed more than once")
+		}
+		return r.key
+	}, WithKeyCache())
+	if calls != len(list) {
+		t.Fatalf("got %d calls, want %d", calls, len(list))
+	}
+	// Elements with equal keys may be ordered differently.
+	if !slices.EqualFunc(list, want, func(a, b record) bool { return a.key == b.key }) {
+		t.Fatal("results differ")
+	}
+}
+
+func TestSortByIntKey(t *testing.T) {
+	list := records(100_000, 1000)
+	for i := range list {
+		list[i].key -= 500
+		if i%1000 == 0 {
+			list[i].key = math.MinInt + i
+		}
+	}
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byKey)
+
+	SortByIntKey(list, func(r record) int { return r.key })
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+
+	SortByIntKey([]record{}, func(r record) int { return r.key })
+}
+
+func BenchmarkSortByIntKey(b *testing.B) {
+	list := records(1_000_000, 1_000_000)
+	key := func(r record) int { return r.key }
+
+	b.Run("SortByIntKey", func(b *testing.B) {
+		s := make([]record, len(list))
+		for i := 0; i < b.N; i++ {
+			copy(s, list)
+			SortByIntKey(s, key)
+		}
+	})
+	b.Run("SortByKey", func(b *testing.B) {
+		s := make([]record, len(list))
+		for i := 0; i < b.N; i++ {
+			copy(s, list)
+			SortByKey(s, key)
+		}
+	})
+}
+
+func TestSortStableByBytes(t *testing.T) {
+	type event struct {
+		at [8]byte
+		id int
+	}
+	byAt := func(a, b event) int { return bytes.Compare(a.at[:], b.at[:]) }
+
+	list := make([]event, 100_000)
+	for i, k := range permutation(len(list)) {
+		// Few distinct keys, differing in both high and low bytes.
+		k %= 1000
+		binary.BigEndian.PutUint64(list[i].at[:], uint64(k)<<40|uint64(k%7))
+		list[i].id = i
+	}
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byAt)
+
+	SortStableByBytes(list, func(e event) [8]byte { return e.at })
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+
+	SortStableByBytes([]event{}, func(e event) [8]byte { return e.at })
+}
+
+func TestSortFloat64Keys(t *testing.T) {
+	type item struct {
+		key float64
+		id  int
+	}
+
+	var list []item
+	for i, f := range floats(100_000) {
+		switch {
+		case i%100 == 0:
+			f = math.NaN()
+		case i%101 == 0:
+			f = math.Inf(i%2*2 - 1)
+		case i%102 == 0:
+			f = math.Copysign(0, float64(i%2*2-1))
+		case i%2 == 0:
+			f = -f
+		}
+		list = append(list, item{f, i})
+	}
+
+	// NaNs go last, zeros are equal, ties stay in order.
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, func(a, b item) int {
+		return cmp.Compare(a.key, b.key)
+	})
+	nans := slices.IndexFunc(want, func(e item) bool { return !math.IsNaN(e.key) })
+	want = append(want[nans:], want[:nans]...)
+
+	SortFloat64Keys(list, func(e item) float64 { return e.key })
+	if !slices.EqualFunc(list, want, func(a, b item) bool { return a.id == b.id }) {
+		t.FailNow()
+	}
+}
+
+func BenchmarkSortFloat64Keys(b *testing.B) {
+	type item struct {
+		key float64
+		id  int
+	}
+
+	list := make([]item, 1_000_000)
+	for i, f := range floats(len(list)) {
+		list[i] = item{f, i}
+	}
+	key := func(e item) float64 { return e.key }
+
+	b.Run("SortFloat64Keys", func(b *testing.B) {
+		s := make([]item, len(list))
+		for i := 0; i < b.N; i++ {
+			copy(s, list)
+			SortFloat64Keys(s, key)
+		}
+	})
+	b.Run("SortFunc", func(b *testing.B) {
+		s := make([]item, len(list))
+		for i := 0; i < b.N; i++ {
+			copy(s, list)
+			SortFunc(s, func(a, b item) int { return cmp.Compare(key(a), key(b)) })
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package quick
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMedianNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int64
+		want float64
+	}{
+		{"single", []int64{7}, 7},
+		{"odd", []int64{5, 1, 4, 2, 3}, 3},
+		{"even", []int64{4, 1, 3, 2}, 2.5},
+		{"ties", []int64{2, 2, 1, 2}, 2},
+		{"large", []int64{math.MaxInt64, math.MaxInt64 - 2}, math.MaxInt64 - 1},
+		{"small", []int64{math.MinInt64, math.MinInt64}, math.MinInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MedianNumber(tt.list); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := MedianNumber([]float64{math.MaxFloat64, math.MaxFloat64}); got != math.MaxFloat64 {
+		t.Errorf("got %v, want %v", got, math.MaxFloat64)
+	}
+	if got := MedianNumber([]uint8{255, 254, 253, 252}); got != 253.5 {
+		t.Errorf("got %v, want %v", got, 253.5)
+	}
+	if got := MedianNumber([]int{}); !math.IsNaN(got) {
+		t.Errorf("got %v, want NaN", got)
+	}
+}
+
+func TestMedianNumber_shapes(t *testing.T) {
+	for _, n := range []int{99_999, 100_000} {
+		list := permutation(n)
+		want := float64(n-1) / 2
+		if got := MedianNumber(list); got != want {
+			t.Errorf("n=%d: got %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		list []int
+		p    float64
+		want float64
+	}{
+		// Checked against numpy.percentile.
+		{[]int{7}, 0, 7},
+		{[]int{7}, 50, 7},
+		{[]int{7}, 100, 7},
+		{[]int{4, 1, 3, 2}, 0, 1},
+		{[]int{4, 1, 3, 2}, 25, 1.75},
+		{[]int{4, 1, 3, 2}, 50, 2.5},
+		{[]int{4, 1, 3, 2}, 100, 4},
+		{[]int{50, 15, 40, 20, 35}, 40, 29},
+		{[]int{50, 15, 40, 20, 35}, 75, 40},
+		{[]int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, 90, 9.1},
+		{[]int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, 10, 1.9},
+	}
+	for _, tt := range tests {
+		got := Percentile(slices.Clone(tt.list), tt.p)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Percentile(%v, %v) = %v, want %v", tt.list, tt.p, got, tt.want)
+		}
+	}
+
+	if got := Percentile([]float64{}, 50); !math.IsNaN(got) {
+		t.Errorf("got %v, want NaN", got)
+	}
+
+	list := permutation(1_000_001)
+	if got := Percentile(list, 50); got != MedianNumber(list) {
+		t.Errorf("got %v, want %v", got, MedianNumber(list))
+	}
+
+	for _, p := range []float64{-1, 101, math.NaN()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("p=%v: want panic", p)
+				}
+			}()
+			Percentile([]int{1}, p)
+		}()
+	}
+}
+
+func TestMedianSmall(t *testing.T) {
+	// All permutations of up to 9 elements.
+	for n := 1; n <= 9; n += 1 {
+		list := sorted(n)
+		var permute func(k int)
+		permute = func(k int) {
+			if k == 1 {
+				s := slices.Clone(list)
+				if got := MedianSmall(s); got != n/2 {
+					t.Fatalf("%v: got %d, want %d", list, got, n/2)
+				}
+				return
+			}
+			for i := 0; i < k; i += 1 {
+				permute(k - 1)
+				if k%2 == 0 {
+					list[i], list[k-1] = list[k-1], list[i]
+				} else {
+					list[0], list[k-1] = list[k-1], list[0]
+				}
+			}
+		}
+		permute(n)
+	}
+
+	// By the 0-1 principle, a network that selects the median
+	// of every input of 0s and 1s selects it for any input.
+	// Bit j of wire i holds s[i] for input x+j: 64 inputs at a time.
+	// As x is a multiple of 64, x+j is x|j: the low 6 wires hold j,
+	// the same for every x, and the others all 0s or all 1s.
+	var low [6]uint64
+	var lowOnes [64]int
+	for j := 0; j < 64; j += 1 {
+		for i := range low {
+			low[i] |= uint64(j>>i&1) << j
+			lowOnes[j] += j >> i & 1
+		}
+	}
+	for n, net := range medianNetworks {
+		if net == nil {
+			continue
+		}
+		wires := make([]uint64, n)
+		for x := 0; x < 1<<n; x += 64 {
+			highOnes := 0
+			for i := range wires {
+				if i < len(low) {
+					wires[i] = low[i]
+				} else {
+					wires[i] = -uint64(x >> i & 1)
+					highOnes += x >> i & 1
+				}
+			}
+			for _, c := range net {
+				a, b := wires[c[0]], wires[c[1]]
+				wires[c[0]], wires[c[1]] = a&b, a|b
+			}
+			for j := 0; j < 64 && x+j < 1<<n; j += 1 {
+				ones := highOnes + lowOnes[j]
+				want := 0
+				if ones >= n-n/2 {
+					want = 1
+				}
+				if got := int(wires[n/2] >> j & 1); got != want {
+					t.Fatalf("n=%d, x=%b: got %d, want %d", n, x+j, got, want)
+				}
+			}
+		}
+	}
+
+	// Other lengths, with NaNs, which are kept.
+	for n := 1; n <= 25; n += 1 {
+		list := make([]float64, n)
+		for i := range list {
+			list[i] = float64(rand.Intn(10))
+		}
+		list[rand.Intn(n)] = math.NaN()
+		want := slices.Clone(list)
+		slices.Sort(want)
+		got := MedianSmall(list)
+		if got != want[n/2] && !(math.IsNaN(got) && math.IsNaN(want[n/2])) {
+			t.Fatalf("n=%d: got %v, want %v", n, got, want[n/2])
+		}
+		slices.Sort(list)
+		if !slices.EqualFunc(list, want, func(a, b float64) bool {
+			return a == b || math.IsNaN(a) && math.IsNaN(b)
+		}) {
+			t.Fatalf("n=%d: elements changed", n)
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("didn't panic")
+			}
+		}()
+		MedianSmall(make([]int, 26))
+	}()
+}
+
+func BenchmarkMedianSmall(b *testing.B) {
+	src := floats(1 << 16)
+	for _, n := range []int{3, 5, 9, 25} {
+		window := make([]float64, n)
+		b.Run(fmt.Sprintf("network/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				o := i % (len(src) - n)
+				copy(window, src[o:])
+				MedianSmall(window)
+			}
+		})
+		b.Run(fmt.Sprintf("select/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				o := i % (len(src) - n)
+				copy(window, src[o:])
+				Select(window, n/2)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package quick
+
+import "cmp"
+
+// MinMax returns the minimal and maximal elements of a slice,
+// like slices.Min and slices.Max, but in a single pass.
+// It compares elements in pairs, the smaller with the minimum,
+// the larger with the maximum: 3 comparisons for every 2 elements.
+// It panics if the slice is empty.
+// It uses O(n) time and O(1) space.
+func MinMax[T cmp.Ordered](s []T) (lo, hi T) {
+	lo = s[0]
+	hi = s[0]
+	for i := len(s) % 2; i < len(s); i += 2 {
+		a, b := s[i], s[i+1]
+		if b < a {
+			a, b = b, a
+		}
+		lo = min(lo, a)
+		hi = max(hi, b)
+	}
+	// Like slices.Min and slices.Max, NaNs propagate.
+	// Any NaN (x != x) went into either lo or hi.
+	switch {
+	case lo != lo:
+		hi = lo
+	case hi != hi:
+		lo = hi
+	}
+	return lo, hi
+}
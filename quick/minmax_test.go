@@ -0,0 +1,70 @@
+package quick
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"single", []int{42}},
+		{"pair", []int{2, 1}},
+		{"zeros", zeros(1001)},
+		{"bits", bits(1000)},
+		{"sorted", sorted(1001)},
+		{"reversed", reversed(1000)},
+		{"pipeorgan", pipeorgan(1000)},
+		{"permutation", permutation(1001)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := MinMax(tt.list)
+			if lo != slices.Min(tt.list) || hi != slices.Max(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+
+	for _, list := range [][]float64{
+		{math.NaN(), 1, 2},
+		{1, math.NaN(), 2},
+		{1, 2, math.NaN()},
+		{1, 2, math.NaN(), 3},
+	} {
+		lo, hi := MinMax(list)
+		if !math.IsNaN(lo) || !math.IsNaN(hi) {
+			t.Errorf("MinMax(%v) = %v, %v", list, lo, hi)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic")
+		}
+	}()
+	MinMax([]int{})
+}
+
+var sink float64
+
+func BenchmarkMinMax(b *testing.B) {
+	list := floats(10_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lo, hi := MinMax(list)
+		sink += lo + hi
+	}
+}
+
+func BenchmarkMinMax_slices(b *testing.B) {
+	list := floats(10_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		lo, hi := slices.Min(list), slices.Max(list)
+		sink += lo + hi
+	}
+}
@@ -0,0 +1,24 @@
+package quick
+
+import (
+	"cmp"
+	"math/rand"
+)
+
+// SelectOnly finds element k of the slice like Select,
+// then shuffles the elements on either side of it,
+// so the only order left in the slice is that
+// s[:k] are not greater, and s[k+1:] not less, than s[k].
+// It uses O(n) time and O(log(n)) space.
+func SelectOnly[T cmp.Ordered](s []T, k int) T {
+	v := Select(s, k)
+	shuffle(s[:k])
+	shuffle(s[k+1:])
+	return v
+}
+
+func shuffle[T any](s []T) {
+	rand.Shuffle(len(s), func(i, j int) {
+		s[i], s[j] = s[j], s[i]
+	})
+}
@@ -0,0 +1,28 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSelectOnly(t *testing.T) {
+	const k = 1111
+
+	var prev []int
+	for i := 0; i < 3; i++ {
+		list := sorted(100_000)
+		if got := SelectOnly(list, k); got != k {
+			t.Fatalf("got %d, want %d", got, k)
+		}
+		if slices.Max(list[:k]) > k || slices.Min(list[k+1:]) < k {
+			t.Fatal("not partitioned")
+		}
+		if slices.IsSorted(list[:k]) || slices.IsSorted(list[k+1:]) {
+			t.Fatal("not shuffled")
+		}
+		if slices.Equal(list, prev) {
+			t.Fatal("not random")
+		}
+		prev = list
+	}
+}
@@ -0,0 +1,307 @@
+package quick
+
+import (
+	"cmp"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"time"
+)
+
+// An Option configures the behavior of SortWith and the Func variants.
+type Option func(*config)
+
+type config struct {
+	stableTies         bool
+	deterministic      bool
+	stdlib             bool
+	descending         bool
+	maxDepth           int
+	indirect           bool
+	verify             bool
+	parallel           bool
+	chunked            bool
+	parThreshold       int
+	maxGoroutines      int
+	tracer             any
+	progress           func(done, total int)
+	selectProgress     func(remaining int)
+	slowCmp            time.Duration
+	slowCmpWarn        func(avg time.Duration)
+	alloc              func(n int) []int
+	runDetection       bool
+	reverseInput       bool
+	shellBase          int
+	maxInsertion       int
+	maxSelection       int
+	deterministicOrder bool
+	minWrites          bool
+	validateDirection  bool
+	binaryInsertion    bool
+	mergeStrategy      MergeStrategy
+	minGallop          int
+	adaptive           bool
+	equalRange         func(start, count int)
+	branchless         bool
+	deferBase          bool
+	leftFirst          bool
+	prefetch           bool
+	linear             bool
+	heapFallback       bool
+	keyCache           bool
+	profile            *Profile
+	rand               *rand.Rand
+}
+
+func newConfig(opts []Option) config {
+	var c config
+	for _, o := range opts {
+		o(&c)
+	}
+	return c
+}
+
+// SortWith uses the Quicksort algorithm to sort a slice,
+// configured by opts.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortWith[T cmp.Ordered](s []T, opts ...Option) {
+	c := newConfig(opts)
+	if c.branchless {
+		sortBranchless(s)
+		return
+	}
+	sortConfig(s, cmp.Compare[T], c)
+}
+
+// SortFirstWith uses the Quickselect and Quicksort algorithms to sort the first k elements of a slice,
+// configured by opts.
+// It uses O(n + k·log(k)) time and O(log(n)) space.
+func SortFirstWith[T cmp.Ordered](s []T, k int, opts ...Option) {
+	SortFirstFunc(s, k, cmp.Compare[T], opts...)
+}
+
+// SelectWith uses the Quickselect algorithm to find element k of the slice,
+// configured by opts,
+// partially sorting the slice around, and returning, s[k].
+// It uses O(n) time and O(log(n)) space.
+func SelectWith[T cmp.Ordered](s []T, k int, opts ...Option) T {
+	return SelectFunc(s, k, cmp.Compare[T], opts...)
+}
+
+// WithStableTies makes SelectFunc deterministic about ties:
+// the element returned at index k is, among those that compare equal to it,
+// the one with the smallest original index.
+// It uses O(n) extra space.
+func WithStableTies() Option {
+	return func(c *config) { c.stableTies = true }
+}
+
+// A Profile accumulates statistics about the sorts it's given to.
+type Profile struct {
+	MaxDepth    int // deepest Quicksort recursion
+	Partitions  int // number of partitions
+	Fallbacks   int // bad pivots replaced by Median-of-medians
+	Comparisons int // number of comparisons
+	Swaps       int // number of swaps, or equivalent shifts
+}
+
+// WithProfile accumulates statistics into p.
+// Profiling surfaces adversarial inputs
+// that trigger the more expensive pivot selection.
+func WithProfile(p *Profile) Option {
+	return func(c *config) { c.profile = p }
+}
+
+// WithDeterministic always uses Median-of-medians to select pivots.
+// This guarantees linear time selection, and O(n·log(n)) time sorting,
+// at the cost of a larger constant factor.
+func WithDeterministic() Option {
+	return func(c *config) { c.deterministic = true }
+}
+
+// WithGuaranteedLinear makes SelectFunc switch to Median-of-medians pivots
+// once partitioning has done more than a few passes worth of work,
+// like Introselect.
+// This guarantees linear time selection, even for adversarial inputs,
+// while typical inputs keep the faster pivot selection.
+func WithGuaranteedLinear() Option {
+	return func(c *config) { c.linear = true }
+}
+
+// WithHeapFallback makes SelectFunc, when it finds a bad pivot,
+// rather than replace it by Median-of-medians,
+// finish the selection with a bounded binary heap,
+// of the k+1 smallest, or n-k largest, elements, whichever is fewer.
+// This guarantees O(n·log(min(k, n-k))) time selection,
+// which beats Median-of-medians' large constant factor for k near the ends:
+// BenchmarkWithHeapFallback finds it 4 times faster there, for adversarial inputs,
+// but over twice as slow for the median.
+func WithHeapFallback() Option {
+	return func(c *config) { c.heapFallback = true }
+}
+
+// WithRand selects random pivots, using a pseudo-random generator
+// seeded with seed.
+// Bad pivots are still replaced by Median-of-medians.
+func WithRand(seed int64) Option {
+	return func(c *config) { c.rand = rand.New(rand.NewSource(seed)) }
+}
+
+// WithStdlibFallback makes SortFunc delegate slices of up to 4096 elements
+// to slices.SortFunc.
+// For float64s, BenchmarkStdlib finds slices.SortFunc 2 to 4 times faster
+// than SortFunc for up to 256 elements, and 1.4 times faster for 4096,
+// but slower than Sort, at every size.
+func WithStdlibFallback() Option {
+	return func(c *config) { c.stdlib = true }
+}
+
+// WithAscending sorts in ascending order if asc is true (the default),
+// or in descending order otherwise.
+// In descending order, Select finds the k-th largest element.
+func WithAscending(asc bool) Option {
+	return func(c *config) { c.descending = !asc }
+}
+
+// WithMaxDepth bounds Quicksort recursion to depth d (at least 1).
+// Deeper subproblems are sorted with Heapsort, which isn't recursive.
+// This bounds stack usage to O(d) space.
+func WithMaxDepth(d int) Option {
+	return func(c *config) { c.maxDepth = max(d, 1) }
+}
+
+// WithHybridShellBase makes SortFunc sort subslices of up to n elements
+// with Shellsort, rather than partition them further.
+// Subslices small enough for Insertion sort still use it.
+// BenchmarkWithHybridShellBase finds no gain for random ints,
+// and makes pipe organ ones slower, for n from 64 to 512.
+func WithHybridShellBase(n int) Option {
+	return func(c *config) { c.shellBase = n }
+}
+
+// WithInsertionThreshold makes SortFunc sort subslices of up to n elements
+// with Insertion sort, rather than partition them further.
+// An n below 1 is taken as 1.
+// The default, 32, is tuned for ints;
+// BenchmarkWithInsertionThreshold sweeps n for a larger element type.
+func WithInsertionThreshold(n int) Option {
+	return func(c *config) { c.maxInsertion = max(n, 1) }
+}
+
+// WithSelectionThreshold makes SelectFunc and SortFirstFunc
+// find the first k elements of a subslice with Selection sort,
+// rather than partition it further.
+// A k below 1 is taken as 1.
+// The default, 4, is tuned for ints;
+// BenchmarkWithSelectionThreshold sweeps k for a larger element type.
+func WithSelectionThreshold(k int) Option {
+	return func(c *config) { c.maxSelection = max(k, 1) }
+}
+
+// InsertionLen is the length of subslices sorted with Insertion sort.
+func (c *config) insertionLen() int {
+	if c.maxInsertion > 0 {
+		return c.maxInsertion
+	}
+	return minLen
+}
+
+// SelectionK is the k below which subslices are handled with Selection sort.
+func (c *config) selectionK() int {
+	if c.maxSelection > 0 {
+		return c.maxSelection
+	}
+	return minK
+}
+
+// WithDeferredBaseCase makes SortFunc leave small subslices unsorted,
+// and sort them all with a single pass of Insertion sort at the end.
+// Partitioning leaves each element within a small subslice
+// of where it belongs, so that pass is fast,
+// and it scans memory once, in order, rather than jump around.
+// That said, BenchmarkWithDeferredBaseCase finds no gain for ints,
+// which are already cache friendly.
+func WithDeferredBaseCase() Option {
+	return func(c *config) { c.deferBase = true }
+}
+
+// WithLeftFirst makes SortFunc always sort the left side of a partition first,
+// recursively, and then the right side, iteratively,
+// so it goes through memory from lower to higher addresses.
+// By default, the smaller side is sorted recursively,
+// which bounds recursion depth to log₂(n).
+// With this option, the left side can be up to 15/16 of the slice,
+// as worse pivots are replaced by Median-of-medians,
+// so depth is bounded to log₁₆⁄₁₅(n), about 11 times more,
+// and usually about twice that of the default.
+// BenchmarkWithLeftFirst finds no difference for random ints.
+func WithLeftFirst() Option {
+	return func(c *config) { c.leftFirst = true }
+}
+
+// WithIndirectSort sorts indices, rather than elements,
+// then moves each element into place once.
+// For large elements, where moves dominate, this can be faster.
+// It uses O(n) extra space.
+func WithIndirectSort() Option {
+	return func(c *config) { c.indirect = true }
+}
+
+// WithSeedFrom selects random pivots, like WithRand,
+// using a seed read from r (8 bytes) each time the option is used.
+// It panics if r can't provide 8 bytes.
+func WithSeedFrom(r io.Reader) Option {
+	return func(c *config) {
+		var buf [8]byte
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			panic("quick: reading seed: " + err.Error())
+		}
+		seed := int64(binary.LittleEndian.Uint64(buf[:]))
+		c.rand = rand.New(rand.NewSource(seed))
+	}
+}
+
+// WithVerify checks that SortFunc sorted the slice,
+// and panics if the comparison function turns out to be inconsistent.
+// This is meant to catch comparison function bugs during development.
+// It uses O(n) time.
+func WithVerify() Option {
+	return func(c *config) { c.verify = true }
+}
+
+// WithParallel makes SortFunc sort large subslices concurrently.
+func WithParallel() Option {
+	return func(c *config) { c.parallel = true }
+}
+
+// WithParallelThreshold sets the length below which
+// WithParallel sorts subslices sequentially.
+func WithParallelThreshold(n int) Option {
+	return func(c *config) { c.parThreshold = max(n, minLen+1) }
+}
+
+// WithMaxGoroutines sets the maximum number of goroutines
+// WithParallel uses, in addition to the calling one.
+// The default is GOMAXPROCS.
+func WithMaxGoroutines(g int) Option {
+	return func(c *config) { c.maxGoroutines = max(g, 0) }
+}
+
+// WithAllocator makes WithIndirectSort, and SortByKey with WithKeyCache,
+// get their index scratch space from f, rather than make.
+// f must return a slice of at least n ints, which may hold anything.
+// Scratch space for elements, or keys,
+// like merge buffers, and SortStableFunc's tags, still comes from make.
+// The slice isn't retained after the sort returns,
+// so it can come from a sync.Pool, or an arena.
+func WithAllocator(f func(n int) []int) Option {
+	return func(c *config) { c.alloc = f }
+}
+
+// Ints returns index scratch space of length n.
+func (c *config) ints(n int) []int {
+	if c.alloc != nil {
+		return c.alloc(n)[:n]
+	}
+	return make([]int, n)
+}
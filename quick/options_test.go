@@ -0,0 +1,517 @@
+package quick
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithProfile(t *testing.T) {
+	t.Run("killer", func(t *testing.T) {
+		var p Profile
+		list := killer(128*1024 - 1)
+		SortWith(list, WithProfile(&p))
+		if !slices.IsSorted(list) {
+			t.FailNow()
+		}
+		if p.Fallbacks == 0 || p.Partitions == 0 {
+			t.Errorf("got %+v", p)
+		}
+	})
+
+	t.Run("permutation", func(t *testing.T) {
+		var p Profile
+		list := permutation(100_000)
+		SortWith(list, WithProfile(&p))
+		if !slices.IsSorted(list) {
+			t.FailNow()
+		}
+		if log2 := int(math.Log2(float64(len(list)))); p.MaxDepth > log2 {
+			t.Errorf("got depth %d, want at most %d", p.MaxDepth, log2)
+		}
+		if p.Partitions == 0 {
+			t.Errorf("got %+v", p)
+		}
+	})
+}
+
+func TestSelectWith(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"deterministic", []Option{WithDeterministic()}},
+		{"rand", []Option{WithRand(42)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := killer(128*1024 - 1)
+			want := slices.Clone(list)
+			slices.Sort(want)
+
+			for _, k := range []int{0, 1111, len(list) / 2, len(list) - 1} {
+				if got := SelectWith(list, k, tt.opts...); got != want[k] {
+					t.Fatalf("k=%d: got %d, want %d", k, got, want[k])
+				}
+			}
+
+			SortWith(list, tt.opts...)
+			if !slices.Equal(list, want) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestWithDeterministic(t *testing.T) {
+	// Comparisons per element shouldn't grow with the size of the input.
+	for _, n := range []int{1 << 12, 1 << 16, 1 << 20, 1 << 22} {
+		var count int
+		list := killer(n - 1)
+		SelectFunc(list, len(list)/2, func(a, b int) int {
+			count += 1
+			return cmp.Compare(a, b)
+		}, WithDeterministic())
+
+		if r := float64(count) / float64(n); r > 16 {
+			t.Errorf("n=%d: %.1f comparisons per element", n, r)
+		}
+	}
+}
+
+func TestWithStdlibFallback(t *testing.T) {
+	for _, n := range []int{0, 1, maxStdlib, maxStdlib + 1, 100_000} {
+		list := permutation(n)
+		SortWith(list, WithStdlibFallback())
+		if !slices.IsSorted(list) {
+			t.Fatalf("n=%d", n)
+		}
+	}
+}
+
+func BenchmarkStdlib(b *testing.B) {
+	for n := 8; n <= maxStdlib; n *= 2 {
+		src := floats(n)
+		buf := make([]float64, n)
+		b.Run(fmt.Sprint("Sort/", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(buf, src)
+				Sort(buf)
+			}
+		})
+		b.Run(fmt.Sprint("SortFunc/", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(buf, src)
+				SortFunc(buf, cmp.Compare[float64])
+			}
+		})
+		b.Run(fmt.Sprint("slices.SortFunc/", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(buf, src)
+				slices.SortFunc(buf, cmp.Compare[float64])
+			}
+		})
+	}
+}
+
+func TestWithAscending(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"deterministic", []Option{WithDeterministic()}},
+		{"rand", []Option{WithRand(42)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append(tt.opts, WithAscending(false))
+			list := permutation(100_000)
+
+			if got := SelectWith(list, 1111, opts...); got != len(list)-1-1111 {
+				t.Fatalf("got %d, want %d", got, len(list)-1-1111)
+			}
+
+			SortWith(list, opts...)
+			if !slices.IsSortedFunc(list, func(a, b int) int { return cmp.Compare(b, a) }) {
+				t.FailNow()
+			}
+
+			SortWith(list, append(opts, WithAscending(true))...)
+			if !slices.IsSorted(list) {
+				t.FailNow()
+			}
+		})
+	}
+
+	// Small slices delegated to the standard library.
+	list := permutation(maxStdlib)
+	SortWith(list, WithStdlibFallback(), WithAscending(false))
+	if !slices.IsSortedFunc(list, func(a, b int) int { return cmp.Compare(b, a) }) {
+		t.Error("stdlib: not descending")
+	}
+}
+
+func TestWithMaxDepth(t *testing.T) {
+	for _, d := range []int{0, 1, 2, 100} {
+		var p Profile
+		list := permutation(100_000)
+		SortWith(list, WithMaxDepth(d), WithProfile(&p))
+		if !slices.IsSorted(list) {
+			t.Fatalf("d=%d", d)
+		}
+		// The profile counts the call that falls back to Heapsort.
+		if p.MaxDepth > max(d, 1)+1 {
+			t.Fatalf("d=%d: got depth %d", d, p.MaxDepth)
+		}
+	}
+}
+
+func TestWithSeedFrom(t *testing.T) {
+	seed := []byte("01234567")
+	input := permutation(100_000)
+
+	var counts []int
+	for i := 0; i < 3; i++ {
+		var count int
+		list := slices.Clone(input)
+		SortFunc(list, func(a, b int) int {
+			count += 1
+			return cmp.Compare(a, b)
+		}, WithSeedFrom(bytes.NewReader(seed)))
+		if !slices.IsSorted(list) {
+			t.FailNow()
+		}
+		counts = append(counts, count)
+	}
+	if counts[0] != counts[1] || counts[1] != counts[2] {
+		t.Errorf("got %v", counts)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("want panic")
+		}
+	}()
+	SortWith([]int{}, WithSeedFrom(bytes.NewReader(seed[:7])))
+}
+
+func TestWithAllocator(t *testing.T) {
+	var sizes []int
+	var buf []int
+	alloc := WithAllocator(func(n int) []int {
+		sizes = append(sizes, n)
+		if cap(buf) < n {
+			buf = make([]int, n)
+		}
+		return buf
+	})
+
+	list := larges(100_000)
+	want := slices.Clone(list)
+	slices.SortFunc(want, byLargeKey)
+
+	SortFunc(list, byLargeKey, WithIndirectSort(), alloc)
+	if !slices.Equal(list, want) {
+		t.Fatal("not sorted")
+	}
+	if !slices.Equal(sizes, []int{len(list)}) {
+		t.Fatalf("got sizes %v", sizes)
+	}
+
+	// With the scratch space reused, sorting shouldn't allocate it.
+	list = list[:1000]
+	with := testing.AllocsPerRun(10, func() {
+		SortFunc(list, byLargeKey, WithIndirectSort(), alloc)
+	})
+	without := testing.AllocsPerRun(10, func() {
+		SortFunc(list, byLargeKey, WithIndirectSort())
+	})
+	if with != without-1 {
+		t.Errorf("got %v allocs, want %v", with, without-1)
+	}
+
+	// Same for the key cache.
+	largeKey := func(e large) float64 { return e.key }
+	with = testing.AllocsPerRun(10, func() {
+		SortByKey(list, largeKey, WithKeyCache(), alloc)
+	})
+	without = testing.AllocsPerRun(10, func() {
+		SortByKey(list, largeKey, WithKeyCache())
+	})
+	if with != without-1 {
+		t.Errorf("key cache: got %v allocs, want %v", with, without-1)
+	}
+}
+
+func TestWithGuaranteedLinear(t *testing.T) {
+	tests := []struct {
+		name string
+		list func(n int) []int
+	}{
+		{"permutation", permutation},
+		{"pipeorgan", pipeorgan},
+		{"killer", killer},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for n := 1 << 12; n <= 1<<20; n <<= 2 {
+				list := tt.list(n)
+				want := slices.Clone(list)
+				slices.Sort(want)
+
+				var count int
+				sel := SelectFunc(list, n/2, func(a, b int) int {
+					count += 1
+					return cmp.Compare(a, b)
+				}, WithGuaranteedLinear())
+				if sel != want[n/2] {
+					t.Fatalf("n=%d: got %d, want %d", n, sel, want[n/2])
+				}
+				if count > 32*n {
+					t.Errorf("n=%d: %d comparisons", n, count)
+				}
+			}
+		})
+	}
+
+	// Selections nested in a sort don't make the rest of it deterministic.
+	var with, without Profile
+	SortFunc(killer(128*1024-1), cmp.Compare, WithProfile(&without))
+	q := newSorter(cmp.Compare[int], []Option{WithGuaranteedLinear(), WithProfile(&with)})
+	q.sortAll(killer(128*1024 - 1))
+	if q.deterministic {
+		t.Error("sort left deterministic")
+	}
+	if with.Comparisons > without.Comparisons*5/4 {
+		t.Errorf("got %d comparisons, want about %d", with.Comparisons, without.Comparisons)
+	}
+}
+
+func TestWithInsertionThreshold(t *testing.T) {
+	for _, n := range []int{-1, 1, 2, 8, minLen, 100} {
+		for _, list := range [][]int{
+			bits(100_000),
+			pipeorgan(100_000),
+			permutation(100_000),
+			killer(128*1024 - 1),
+		} {
+			SortWith(list, WithInsertionThreshold(n))
+			if !slices.IsSorted(list) {
+				t.Fatalf("n=%d: not sorted", n)
+			}
+		}
+		list := cardinality(100_000, 100)
+		SortWith(list, WithInsertionThreshold(n), WithAdaptivePartition())
+		if !slices.IsSorted(list) {
+			t.Fatalf("n=%d: not sorted", n)
+		}
+	}
+}
+
+func TestWithSelectionThreshold(t *testing.T) {
+	for _, k := range []int{-1, 1, 2, minK, 16, 100} {
+		for _, list := range [][]int{
+			bits(100_000),
+			pipeorgan(100_000),
+			permutation(100_000),
+			killer(128*1024 - 1),
+		} {
+			want := slices.Clone(list)
+			slices.Sort(want)
+			for _, i := range []int{0, 1, 50, 1111, len(list) - 1} {
+				if got := SelectWith(list, i, WithSelectionThreshold(k)); got != want[i] {
+					t.Fatalf("k=%d, i=%d: got %d, want %d", k, i, got, want[i])
+				}
+				SortFirstWith(list, i, WithSelectionThreshold(k), WithInsertionThreshold(k))
+				if !slices.Equal(list[:i], want[:i]) {
+					t.Fatalf("k=%d, i=%d: first not sorted", k, i)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkWithInsertionThreshold(b *testing.B) {
+	src := larges(100_000)
+	list := make([]large, len(src))
+	for _, n := range []int{4, 8, 16, 32, 64} {
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortFunc(list, byLargeKey, WithInsertionThreshold(n))
+			}
+		})
+	}
+}
+
+func BenchmarkWithSelectionThreshold(b *testing.B) {
+	src := larges(100_000)
+	list := make([]large, len(src))
+	for _, k := range []int{1, 2, 4, 8, 16} {
+		b.Run(strconv.Itoa(k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SelectFunc(list, len(list)/2, byLargeKey, WithSelectionThreshold(k))
+			}
+		})
+	}
+}
+
+func TestWithHybridShellBase(t *testing.T) {
+	for _, n := range []int{0, minLen, 64, 256, 1024} {
+		for _, list := range [][]int{
+			bits(100_000),
+			pipeorgan(100_000),
+			permutation(100_000),
+			killer(128*1024 - 1),
+		} {
+			SortFunc(list, cmp.Compare, WithHybridShellBase(n))
+			if !slices.IsSorted(list) {
+				t.Fatalf("n=%d: not sorted", n)
+			}
+		}
+	}
+}
+
+func BenchmarkWithHybridShellBase(b *testing.B) {
+	for _, tt := range []struct {
+		name string
+		list []int
+	}{
+		{"permutation", permutation(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+	} {
+		list := make([]int, len(tt.list))
+		for _, n := range []int{0, 64, 128, 256, 512} {
+			b.Run(fmt.Sprintf("%s/%d", tt.name, n), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					copy(list, tt.list)
+					SortFunc(list, cmp.Compare, WithHybridShellBase(n))
+				}
+			})
+		}
+	}
+}
+
+func TestWithDeferredBaseCase(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			// Before the final pass, no element is further than minLen
+			// from where it belongs.
+			q := newSorter(cmp.Compare[int], []Option{WithDeferredBaseCase()})
+			q.sort(tt.list)
+			for i, v := range tt.list {
+				lo, _ := slices.BinarySearch(want, v)
+				hi, _ := slices.BinarySearch(want, v+1)
+				if i < lo-minLen || i >= hi+minLen {
+					t.Fatalf("%d at %d, belongs in [%d, %d)", v, i, lo, hi)
+				}
+			}
+
+			q.insertion(tt.list)
+			if !slices.Equal(tt.list, want) {
+				t.Fatal("not sorted")
+			}
+		})
+	}
+
+	list := permutation(100_000)
+	SortFirstFunc(list, 5000, cmp.Compare[int], WithDeferredBaseCase())
+	if !slices.Equal(list[:5000], sorted(5000)) {
+		t.Error("SortFirstFunc: not sorted")
+	}
+}
+
+func BenchmarkWithDeferredBaseCase(b *testing.B) {
+	src := permutation(10_000_000)
+	list := make([]int, len(src))
+	for _, deferred := range []bool{false, true} {
+		b.Run(fmt.Sprint(deferred), func(b *testing.B) {
+			var opts []Option
+			if deferred {
+				opts = append(opts, WithDeferredBaseCase())
+			}
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortFunc(list, cmp.Compare, opts...)
+			}
+		})
+	}
+}
+
+func TestWithLeftFirst(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log2 := math.Log2(float64(len(tt.list)))
+
+			var off Profile
+			list := slices.Clone(tt.list)
+			SortWith(list, WithProfile(&off))
+			if !slices.IsSorted(list) {
+				t.FailNow()
+			}
+			if off.MaxDepth > int(log2) {
+				t.Errorf("got depth %d, want at most %d", off.MaxDepth, int(log2))
+			}
+
+			var on Profile
+			SortWith(tt.list, WithLeftFirst(), WithProfile(&on))
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+			if max := int(log2 / math.Log2(16.0/15)); on.MaxDepth > max {
+				t.Errorf("got depth %d, want at most %d", on.MaxDepth, max)
+			}
+		})
+	}
+}
+
+func BenchmarkWithLeftFirst(b *testing.B) {
+	src := permutation(10_000_000)
+	list := make([]int, len(src))
+	for _, left := range []bool{false, true} {
+		b.Run(fmt.Sprint(left), func(b *testing.B) {
+			var opts []Option
+			if left {
+				opts = append(opts, WithLeftFirst())
+			}
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortWith(list, opts...)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package quick
+
+import (
+	"cmp"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+const minParallel = 1 << 16 // at least minLen
+
+// SortParallel uses the Quicksort algorithm to sort a slice,
+// sorting large subslices concurrently.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortParallel[T cmp.Ordered](s []T) {
+	SortWith(s, WithParallel())
+}
+
+// SelectParallel uses a parallel variant of the Quickselect algorithm
+// to find element k of the slice,
+// partially sorting the slice around, and returning, s[k].
+// Each partition splits the slice into chunks, one per CPU,
+// counts the elements less than, equal to, and greater than the pivot in each chunk,
+// and uses those counts to scatter the elements into a buffer, in parallel.
+// Small subslices are handed to Select.
+// It uses O(n) time and O(n) space.
+func SelectParallel[T cmp.Ordered](s []T, k int) T {
+	// This does a bounds check before making any changes to the slice.
+	_ = s[k]
+
+	buf := make([]T, len(s))
+	for len(s) >= minParallel {
+		n := len(s)
+		lt, eq := parallelPartition(s, buf, samplePivot(s))
+		switch {
+		case k < lt:
+			s = s[:lt]
+			buf = buf[:lt]
+		case k < lt+eq:
+			return s[k]
+		default:
+			s = s[lt+eq:]
+			buf = buf[lt+eq:]
+			k -= lt + eq
+		}
+		// Don't insist on bad pivots.
+		if len(s) > n-n/minRatio {
+			break
+		}
+	}
+	return Select(s, k)
+}
+
+// SamplePivot returns the median of evenly spaced elements of s.
+func samplePivot[T cmp.Ordered](s []T) T {
+	var sample [31]T
+	for i := range sample {
+		sample[i] = s[i*(len(s)-1)/(len(sample)-1)]
+	}
+	return Select(sample[:], len(sample)/2)
+}
+
+// ParallelPartition rearranges s into elements less than, equal to,
+// and greater than p, returning the number of the first two.
+// It uses buf, which must be as long as s, as scratch space.
+// It uses O(n) time and O(n) space.
+func parallelPartition[T cmp.Ordered](s, buf []T, p T) (lt, eq int) {
+	type counts struct{ lt, eq, gt int }
+
+	n := runtime.GOMAXPROCS(0)
+	chunk := func(c int) []T {
+		return s[c*len(s)/n : (c+1)*len(s)/n]
+	}
+
+	count := make([]counts, n)
+	parallel(n, func(c int) {
+		var cnt counts
+		for _, v := range chunk(c) {
+			switch {
+			case cmp.Less(v, p):
+				cnt.lt += 1
+			case cmp.Less(p, v):
+				cnt.gt += 1
+			default:
+				cnt.eq += 1
+			}
+		}
+		count[c] = cnt
+	})
+
+	// Turn counts into offsets into buf.
+	var total counts
+	for _, cnt := range count {
+		total.lt += cnt.lt
+		total.eq += cnt.eq
+	}
+	off := counts{0, total.lt, total.lt + total.eq}
+	for c, cnt := range count {
+		count[c] = off
+		off.lt += cnt.lt
+		off.eq += cnt.eq
+		off.gt += cnt.gt
+	}
+
+	parallel(n, func(c int) {
+		off := count[c]
+		for _, v := range chunk(c) {
+			switch {
+			case cmp.Less(v, p):
+				buf[off.lt] = v
+				off.lt += 1
+			case cmp.Less(p, v):
+				buf[off.gt] = v
+				off.gt += 1
+			default:
+				buf[off.eq] = v
+				off.eq += 1
+			}
+		}
+	})
+
+	parallel(n, func(c int) {
+		lo := c * len(s) / n
+		copy(chunk(c), buf[lo:])
+	})
+	return total.lt, total.eq
+}
+
+// ParallelState is shared by all goroutines of a parallel sort.
+type parallelState struct {
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	sem     chan struct{}
+	profile *Profile
+}
+
+func (q *sorter[T]) sortParallel(s []T) {
+	par := &parallelState{profile: q.profile}
+	if q.parThreshold == 0 {
+		q.parThreshold = minParallel
+	}
+	if q.maxGoroutines == 0 {
+		q.maxGoroutines = runtime.GOMAXPROCS(0)
+	}
+	par.sem = make(chan struct{}, q.maxGoroutines)
+
+	c := q.fork(par)
+	c.sort(s)
+	par.wg.Wait()
+	c.join()
+}
+
+// Spawn sorts s, concurrently if it's large enough,
+// and a goroutine is available.
+func (q *sorter[T]) spawn(s []T) {
+	if q.par != nil && len(s) >= q.parThreshold {
+		select {
+		case q.par.sem <- struct{}{}:
+			c := q.fork(q.par)
+			q.par.wg.Add(1)
+			go func() {
+				defer q.par.wg.Done()
+				c.sort(s)
+				c.join()
+				<-q.par.sem
+			}()
+			return
+		default:
+		}
+	}
+	q.sort(s)
+}
+
+// Fork copies the sorter for use by another goroutine,
+// giving it a profile, and a pseudo-random generator, of its own,
+// seeded from that of q.
+// It must be called from the goroutine that uses q.
+func (q *sorter[T]) fork(par *parallelState) *sorter[T] {
+	c := *q
+	c.par = par
+	if c.profile != nil {
+		c.profile = &Profile{}
+	}
+	if c.rand != nil {
+		c.rand = rand.New(rand.NewSource(q.rand.Int63()))
+	}
+	return &c
+}
+
+// Join merges the profile of a forked sorter.
+func (q *sorter[T]) join() {
+	if p := q.par.profile; p != nil {
+		q.par.mu.Lock()
+		p.MaxDepth = max(p.MaxDepth, q.profile.MaxDepth)
+		p.Partitions += q.profile.Partitions
+		p.Fallbacks += q.profile.Fallbacks
+		p.Comparisons += q.profile.Comparisons
+		p.Swaps += q.profile.Swaps
+		q.par.mu.Unlock()
+	}
+}
+
+// Parallel calls f(0), f(1), …, f(n-1) concurrently,
+// and waits for them to return.
+func parallel(n int, f func(int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i += 1 {
+		go func(i int) {
+			defer wg.Done()
+			f(i)
+		}(i)
+	}
+	wg.Wait()
+}
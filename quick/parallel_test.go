@@ -0,0 +1,186 @@
+package quick
+
+import (
+	"flag"
+	"fmt"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+var parallelSize = flag.Int("parallel.n", 10_000_000, "elements to sort in BenchmarkSortParallel (try 50_000_000)")
+var selectSize = flag.Int("select.n", 10_000_000, "elements to select from in BenchmarkSelectParallel (try 100_000_000)")
+
+func TestSortParallel(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortParallel(tt.list)
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestWithParallel(t *testing.T) {
+	input := permutation(300_000)
+	for _, n := range []int{0, 1000, minParallel} {
+		for _, g := range []int{0, 1, 4, 64} {
+			t.Run(fmt.Sprint(n, "/", g), func(t *testing.T) {
+				var p Profile
+				list := slices.Clone(input)
+				SortWith(list, WithParallel(), WithProfile(&p),
+					WithParallelThreshold(n), WithMaxGoroutines(g))
+				if !slices.IsSorted(list) {
+					t.FailNow()
+				}
+				if p.Partitions == 0 || p.MaxDepth == 0 {
+					t.Errorf("got %+v", p)
+				}
+			})
+		}
+	}
+
+	// Each goroutine gets its own generator.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	for _, opt := range []Option{WithParallel(), WithNUMAAwareChunking()} {
+		list := slices.Clone(input)
+		SortWith(list, opt, WithRand(1), WithParallelThreshold(1000))
+		if !slices.IsSorted(list) {
+			t.Fatal("not sorted")
+		}
+	}
+}
+
+func TestSelectParallel(t *testing.T) {
+	// Large enough for a few partitions of at least minParallel elements.
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(400_000)},
+		{"bits", bits(400_000)},
+		{"sorted", sorted(400_000)},
+		{"reversed", reversed(400_000)},
+		{"pipeorgan", pipeorgan(400_000)},
+		{"permutation", permutation(400_000)},
+		{"killer", killer(512*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []int{0, 1111, len(tt.list) / 2, len(tt.list) - 1} {
+				list := slices.Clone(tt.list)
+				want := Select(slices.Clone(tt.list), k)
+				if got := SelectParallel(list, k); got != want {
+					t.Fatalf("k=%d: got %d, want %d", k, got, want)
+				}
+				if list[k] != want ||
+					slices.Max(list[:k+1]) != want ||
+					slices.Min(list[k:]) != want {
+					t.Fatalf("k=%d: not partitioned", k)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSortParallel(b *testing.B) {
+	src := floats(*parallelSize)
+	for _, n := range []int{1 << 10, 1 << 13, 1 << 16, 1 << 19, 1 << 22} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.StopTimer()
+			list := slices.Clone(src)
+			b.StartTimer()
+			SortWith(list, WithParallel(), WithParallelThreshold(n))
+		})
+	}
+}
+
+func BenchmarkSelectParallel(b *testing.B) {
+	src := floats(*selectSize)
+	list := make([]float64, len(src))
+	k := len(src) / 10
+	b.Run("Select", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			copy(list, src)
+			b.StartTimer()
+			Select(list, k)
+		}
+	})
+	b.Run("SelectParallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			copy(list, src)
+			b.StartTimer()
+			SelectParallel(list, k)
+		}
+	})
+}
+
+func TestWithNUMAAwareChunking(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	// Large enough for several chunks of minParallel elements.
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(400_000)},
+		{"bits", bits(400_000)},
+		{"sorted", sorted(400_000)},
+		{"reversed", reversed(400_000)},
+		{"pipeorgan", pipeorgan(400_000)},
+		{"permutation", permutation(400_000)},
+		{"killer", killer(512*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, g := range []int{0, 3, 5} {
+				list := slices.Clone(tt.list)
+				SortWith(list, WithNUMAAwareChunking(), WithMaxGoroutines(g))
+				if !slices.IsSorted(list) {
+					t.Fatalf("g=%d: not sorted", g)
+				}
+			}
+		})
+	}
+
+	t.Run("deferred", func(t *testing.T) {
+		// Chunks must be fully sorted before they're merged.
+		list := records(400_000, 100)
+		SortFunc(list, byKey, WithNUMAAwareChunking(), WithDeferredBaseCase())
+		if !slices.IsSortedFunc(list, byKey) {
+			t.FailNow()
+		}
+	})
+}
+
+func BenchmarkWithNUMAAwareChunking(b *testing.B) {
+	src := permutation(10_000_000)
+	list := make([]int, len(src))
+	b.Run("WithParallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortWith(list, WithParallel())
+		}
+	})
+	b.Run("WithNUMAAwareChunking", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortWith(list, WithNUMAAwareChunking())
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package quick
+
+// StablePartition moves the elements of s that don't satisfy pred to the front,
+// followed by those that do, keeping the relative order of both,
+// and returns the number of the former.
+// Elements that don't satisfy pred are moved in place;
+// those that do are held in a buffer, until they're moved after them.
+// It uses O(n) time and O(n) space.
+func StablePartition[T any](s []T, pred func(T) bool) int {
+	var buf []T
+	i := 0
+	for _, v := range s {
+		if pred(v) {
+			buf = append(buf, v)
+		} else {
+			s[i] = v
+			i += 1
+		}
+	}
+	copy(s[i:], buf)
+	return i
+}
+
+// Partition moves the elements of s that don't satisfy pred to the front,
+// followed by those that do, in no particular order,
+// and returns the number of the former.
+// Like StablePartition, elements that satisfy pred go last:
+// s[:i] fail pred, s[i:] pass it, like a sort of pred(v) from false to true.
+// It scans from both ends, swapping misplaced pairs,
+// like Hoare's partition scheme.
+// It uses O(n) time and O(1) space.
+func Partition[T any](s []T, pred func(T) bool) int {
+	i, j := 0, len(s)-1
+	for {
+		for i <= j && !pred(s[i]) {
+			i += 1
+		}
+		for i < j && pred(s[j]) {
+			j -= 1
+		}
+		if i >= j {
+			return i
+		}
+		s[i], s[j] = s[j], s[i]
+		i += 1
+		j -= 1
+	}
+}
@@ -0,0 +1,66 @@
+package quick
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+func TestStablePartition(t *testing.T) {
+	even := func(r record) bool { return r.key%2 == 0 }
+
+	for _, n := range []int{0, 1, 2, 100, 100_000} {
+		list := records(n, 1000)
+
+		// Filtering keeps the relative order of each group.
+		var want []record
+		for _, r := range list {
+			if !even(r) {
+				want = append(want, r)
+			}
+		}
+		split := len(want)
+		for _, r := range list {
+			if even(r) {
+				want = append(want, r)
+			}
+		}
+
+		if got := StablePartition(list, even); got != split {
+			t.Fatalf("n=%d: got split %d, want %d", n, got, split)
+		}
+		if !slices.Equal(list, want) {
+			t.Fatalf("n=%d: order not preserved", n)
+		}
+	}
+}
+
+func FuzzPartitionPredicate(f *testing.F) {
+	f.Add([]byte("the quick brown fox jumps over the lazy dog"))
+	f.Add([]byte{})
+	f.Add([]byte{1})
+	f.Add([]byte{0, 1})
+
+	odd := func(b byte) bool { return b%2 != 0 }
+	f.Fuzz(func(t *testing.T, s []byte) {
+		orig := slices.Clone(s)
+		i := Partition(s, odd)
+
+		if i < 0 || i > len(s) {
+			t.Fatalf("got split %d of %d", i, len(s))
+		}
+		if slices.ContainsFunc(s[:i], odd) {
+			t.Fatalf("%v: an element before %d passes", s, i)
+		}
+		if slices.ContainsFunc(s[i:], func(b byte) bool { return !odd(b) }) {
+			t.Fatalf("%v: an element from %d fails", s, i)
+		}
+
+		// It's a permutation of the input.
+		slices.Sort(orig)
+		slices.Sort(s)
+		if !bytes.Equal(s, orig) {
+			t.Fatal("not a permutation")
+		}
+	})
+}
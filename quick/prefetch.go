@@ -0,0 +1,53 @@
+package quick
+
+import "runtime"
+
+const prefetchDist = 8 // elements ahead
+
+// WithPrefetch makes SortFunc, while partitioning,
+// read elements a few positions ahead of those it compares,
+// hoping to hide memory latency.
+//
+// This is an experiment: Go has no prefetch intrinsics,
+// so this can only read the elements themselves, not what they point to,
+// and hardware prefetchers already handle sequential reads.
+// BenchmarkWithPrefetch finds it makes no difference
+// (within noise) for pointers to scattered structs.
+// And elements are copied to be read, which is costly for large structs.
+func WithPrefetch() Option {
+	return func(c *config) { c.prefetch = true }
+}
+
+// HoarePartitionPrefetch is hoarePartition,
+// reading elements prefetchDist positions ahead of each scan,
+// so the default partition loop doesn't pay for this option.
+func (q *sorter[T]) hoarePartitionPrefetch(s []T, p T) int {
+	var sink T
+	r := len(s) - 1
+	i := 0
+	j := r
+	for {
+		for i < r && q.lessAt(s, s[i], p, i, -1) {
+			if k := i + prefetchDist; k < len(s) {
+				sink = s[k]
+			}
+			i += 1
+		}
+		for j > 0 && q.lessAt(s, p, s[j], -1, j) {
+			if k := j - prefetchDist; k >= 0 {
+				sink = s[k]
+			}
+			j -= 1
+		}
+		if i > j {
+			// The elements read are kept alive, so the reads aren't optimized away.
+			runtime.KeepAlive(sink)
+			return i
+		}
+		if i < j {
+			q.swap(s, i, j)
+		}
+		i += 1
+		j -= 1
+	}
+}
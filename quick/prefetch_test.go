@@ -0,0 +1,78 @@
+package quick
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithPrefetch(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SortWith(tt.list, WithPrefetch())
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+
+	t.Run("pointers", func(t *testing.T) {
+		list := nodes(100_000)
+		SortFunc(list, byNodeKey, WithPrefetch())
+		if !slices.IsSortedFunc(list, byNodeKey) {
+			t.FailNow()
+		}
+	})
+}
+
+func BenchmarkWithPrefetch(b *testing.B) {
+	src := nodes(1_000_000)
+	list := make([]*node, len(src))
+	for _, prefetch := range []bool{false, true} {
+		b.Run("pointers/"+strconv.FormatBool(prefetch), func(b *testing.B) {
+			var opts []Option
+			if prefetch {
+				opts = append(opts, WithPrefetch())
+			}
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortFunc(list, byNodeKey, opts...)
+			}
+		})
+	}
+}
+
+// A node is a struct that's compared through a pointer.
+type node struct {
+	key int
+	_   [120]byte
+}
+
+func byNodeKey(a, b *node) int { return cmp.Compare(a.key, b.key) }
+
+// Nodes returns n pointers to nodes, scattered in memory.
+func nodes(n int) []*node {
+	arena := make([]node, n)
+	list := make([]*node, n)
+	for i, k := range permutation(n) {
+		arena[i].key = k
+		list[i] = &arena[i]
+	}
+	// Pointers no longer follow memory order.
+	rand.Shuffle(n, func(i, j int) { list[i], list[j] = list[j], list[i] })
+	return list
+}
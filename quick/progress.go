@@ -0,0 +1,63 @@
+package quick
+
+import "sync"
+
+// WithProgress makes SortFunc call f periodically,
+// with an estimate of how many of the total elements are done.
+//
+// Quicksort doesn't know how close it is to finishing:
+// partitions only narrow down where elements go.
+// So an element is counted as done only once the subslice that contains it
+// is sorted by the base case.
+// The estimate is non-decreasing, and reaches total when the sort finishes.
+// Calls are throttled to about a hundred per sort.
+func WithProgress(f func(done, total int)) Option {
+	return func(c *config) { c.progress = f }
+}
+
+// WithSelectProgress makes SelectFunc call f before each partition,
+// with the length of the subslice that still holds element k,
+// and with 0 once it's found.
+// The length is strictly decreasing,
+// and, for typical inputs, shrinks geometrically.
+func WithSelectProgress(f func(remaining int)) Option {
+	return func(c *config) { c.selectProgress = f }
+}
+
+// Progress tracks the elements sorted so far.
+// It's shared by all goroutines of a parallel sort.
+type progress struct {
+	mu    sync.Mutex
+	f     func(done, total int)
+	done  int
+	last  int
+	step  int
+	total int
+}
+
+func newProgress(f func(done, total int), total int) *progress {
+	return &progress{f: f, total: total, step: max(total/100, minCheck)}
+}
+
+// Placed counts n more elements as done.
+func (q *sorter[T]) placed(n int) {
+	p := q.prog
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.done += n
+	if p.done-p.last >= p.step {
+		p.last = p.done
+		p.f(p.done, p.total)
+	}
+	p.mu.Unlock()
+}
+
+// Finish reports that all elements are done.
+func (q *sorter[T]) finish() {
+	if p := q.prog; p != nil && p.last < p.total {
+		p.last = p.total
+		p.f(p.total, p.total)
+	}
+}
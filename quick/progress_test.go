@@ -0,0 +1,87 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestWithProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+		opts []Option
+	}{
+		{"empty", nil, nil},
+		{"small", permutation(10), nil},
+		{"permutation", permutation(100_000), nil},
+		{"killer", killer(128*1024 - 1), nil},
+		{"maxdepth", permutation(100_000), []Option{WithMaxDepth(4)}},
+		{"parallel", permutation(100_000), []Option{WithParallel()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var calls, last int
+			progress := WithProgress(func(done, total int) {
+				mu.Lock()
+				defer mu.Unlock()
+				if total != len(tt.list) || done < last || done > total {
+					t.Errorf("progress %d/%d after %d", done, total, last)
+				}
+				calls += 1
+				last = done
+			})
+
+			SortFunc(tt.list, cmp.Compare, append(tt.opts, progress)...)
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			if last != len(tt.list) {
+				t.Errorf("ended at %d, want %d", last, len(tt.list))
+			}
+			if calls > 101 {
+				t.Errorf("got %d calls", calls)
+			}
+		})
+	}
+}
+
+func TestWithSelectProgress(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			var calls []int
+			k := len(want) / 3
+			sel := SelectWith(tt.list, k, WithSelectProgress(func(remaining int) {
+				calls = append(calls, remaining)
+			}))
+			if sel != want[k] {
+				t.Fatalf("got %d, want %d", sel, want[k])
+			}
+			if len(calls) < 2 || calls[0] != len(want) || calls[len(calls)-1] != 0 {
+				t.Fatalf("got %v", calls)
+			}
+			for i := 1; i < len(calls); i++ {
+				if calls[i] >= calls[i-1] {
+					t.Fatalf("got %v", calls)
+				}
+			}
+		})
+	}
+}
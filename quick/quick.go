@@ -4,7 +4,11 @@
 // when a bad pivot is detected.
 package quick
 
-import "cmp"
+import (
+	"cmp"
+
+	"github.com/ncruces/sort/heap"
+)
 
 const (
 	minLen    = 32 // at least 1
@@ -12,6 +16,11 @@ const (
 	minMed3   = 32 // at least 1
 	minRatio  = 16 // at least 4
 	minMedMed = 128
+	maxStdlib = 4096 // largest size in BenchmarkStdlib
+	maxHeapK  = 16   // at least 1
+	minHeapN  = 64   // times k
+	maxRest   = 128  // n over n-k
+	maxWork   = 4    // times n
 )
 
 // Sort uses the Quicksort algorithm to sort a slice.
@@ -34,11 +43,33 @@ func Sort[T cmp.Ordered](s []T) {
 }
 
 // SortFirst uses the Quickselect and Quicksort algorithms to sort the first k elements of a slice.
+// For very small k, it uses a bounded heap instead,
+// and for k very close to len(s), it just sorts the whole slice.
 // It uses O(n + k·log(k)) time and O(log(n)) space.
 func SortFirst[T cmp.Ordered](s []T, k int) {
 	// This does a bounds check before making any changes to the slice.
 	_ = s[:k]
 
+	// The strategies, and crossovers, measured by BenchmarkSortFirst:
+	// - up to maxHeapK, if n is at least minHeapN times k: a bounded heap;
+	// - up to n - n/maxRest: Quickselect, then Quicksort the rest;
+	// - otherwise: Quicksort all of it.
+	// Quickselect only skips sorting s[k:], so it saves little for large k;
+	// still, it beats Sort up to about 99% of n.
+	if len(s)-k <= len(s)/maxRest {
+		Sort(s)
+		return
+	}
+
+	// A bounded heap does a single scan of the slice,
+	// in O(n·log(k)) time, which is faster for small k.
+	// It does up to log(k) times more work for adversarial inputs,
+	// so limit it to really small k.
+	if k <= maxHeapK && k*minHeapN <= len(s) {
+		heap.SortFirst(s, k)
+		return
+	}
+
 	// We could check for len(s) > 1, and use Quickselect all the way down.
 	// In practise, Selection sort performs better for small k.
 	for k > minK {
@@ -76,6 +107,50 @@ func Select[T cmp.Ordered](s []T, k int) T {
 	return s[k]
 }
 
+// SelectLargest uses the Quickselect algorithm to find the k-th largest element of the slice,
+// counting from 0, partially sorting the slice around, and returning, s[len(s)-1-k].
+// It uses O(n) time and O(log(n)) space.
+func SelectLargest[T cmp.Ordered](s []T, k int) T {
+	// This does a bounds check before making any changes to the slice.
+	_ = s[k]
+
+	return Select(s, len(s)-1-k)
+}
+
+// SelectPair uses the Quickselect algorithm to find elements i and j of the slice, i ≤ j,
+// partially sorting the slice around, and returning, s[i] and s[j].
+// Elements between them, s[i+1:j], are the rest of those ranked from i to j, unordered,
+// so a range query can scan them.
+// Both are found by the same partitions, until these split i from j,
+// which is faster than two independent selections.
+// It panics if i > j.
+// It uses O(n) time and O(log(n)) space.
+func SelectPair[T cmp.Ordered](s []T, i, j int) (T, T) {
+	// This does a bounds check before making any changes to the slice.
+	_ = s[i:j]
+	_ = s[j]
+
+	for len(s) > minLen {
+		p := partition(s)
+		switch {
+		case p <= i:
+			s = s[p:]
+			i -= p
+			j -= p
+		case p > j:
+			s = s[:p]
+		default:
+			return Select(s[:p], i), Select(s[p:], j-p)
+		}
+	}
+	// After selecting element i, element j is among those that follow.
+	a := Select(s, i)
+	if i == j {
+		return a, a
+	}
+	return a, Select(s[i+1:], j-i-1)
+}
+
 // Partition is the core of the Quicksort and Quickselect algorithms.
 // This bit only does pivot selection:
 // - the middle element for small slices,
@@ -2,8 +2,10 @@ package quick
 
 import (
 	"cmp"
+	"math"
 	"math/rand"
 	"slices"
+	"strconv"
 	"testing"
 )
 
@@ -30,6 +32,34 @@ func TestSort(t *testing.T) {
 	}
 }
 
+func TestSort_depth(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(1_000_000)},
+		{"sorted", sorted(1_000_000)},
+		{"reversed", reversed(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+		{"killer", killer(1024*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Sort has no depth counter, but SortFunc mirrors it,
+			// recursing into the smaller side, and looping on the larger,
+			// so recursion, and stack usage, is bounded by log₂(n).
+			var p Profile
+			SortFunc(tt.list, cmp.Compare, WithProfile(&p))
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			if log2 := int(math.Log2(float64(len(tt.list)))); p.MaxDepth > log2 {
+				t.Errorf("got depth %d, want at most %d", p.MaxDepth, log2)
+			}
+		})
+	}
+}
+
 func TestSortFirst(t *testing.T) {
 	tests := []struct {
 		name string
@@ -52,6 +82,36 @@ func TestSortFirst(t *testing.T) {
 	}
 }
 
+func TestSortFirst_small(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			n := len(tt.list)
+			for _, k := range []int{0, 1, minK, maxHeapK, maxHeapK + 1, 1111,
+				n - n/maxRest - 1, n - n/maxRest, n - 1, n} {
+				list := slices.Clone(tt.list)
+				SortFirst(list, k)
+				if !slices.Equal(list[:k], want[:k]) {
+					t.Fatalf("k=%d", k)
+				}
+			}
+		})
+	}
+}
+
 func TestSelect(t *testing.T) {
 	tests := []struct {
 		name string
@@ -75,6 +135,72 @@ func TestSelect(t *testing.T) {
 	}
 }
 
+func TestSelectLargest(t *testing.T) {
+	list := permutation(100_000)
+	want := slices.Clone(list)
+	slices.Sort(want)
+	slices.Reverse(want)
+
+	for _, k := range []int{0, 1, 1111, 50_000, len(list) - 1} {
+		if sel := SelectLargest(list, k); sel != want[k] {
+			t.Fatalf("k=%d: got %d, want %d", k, sel, want[k])
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("didn't panic")
+			}
+		}()
+		SelectLargest(list, len(list))
+	}()
+}
+
+func TestSelectPair(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			for _, r := range [][2]int{{0, 0}, {0, 1}, {3, 1111}, {1111, 1111}, {50_000, 50_010}, {10, len(want) - 1}} {
+				i, j := r[0], r[1]
+				list := slices.Clone(tt.list)
+				a, b := SelectPair(list, i, j)
+				if a != want[i] || b != want[j] || list[i] != a || list[j] != b {
+					t.Fatalf("(%d, %d): got %d, %d, want %d, %d", i, j, a, b, want[i], want[j])
+				}
+				// Between them are exactly the ranks i to j.
+				between := slices.Clone(list[i : j+1])
+				slices.Sort(between)
+				if !slices.Equal(between, want[i:j+1]) {
+					t.Fatalf("(%d, %d): wrong elements between", i, j)
+				}
+			}
+		})
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("didn't panic")
+			}
+		}()
+		SelectPair(permutation(100), 10, 9)
+	}()
+}
+
 func TestInsertion(t *testing.T) {
 	tests := []struct {
 		name string
@@ -127,11 +253,20 @@ func TestBounds(t *testing.T) {
 	SortFirst([]int{0}, 1)
 
 	Select([]int{0}, 0)
+	SelectLargest([]int{0}, 0)
 
 	partition([]int{0})
 	insertion[int](nil)
 	selection[int](nil, 0)
 	medianOfMedians([]int{0})
+
+	SortFunc[int](nil, cmp.Compare)
+	SortFirstFunc[int](nil, 0, cmp.Compare)
+	SelectFunc([]int{0}, 0, cmp.Compare)
+	SelectFunc([]int{0}, 0, cmp.Compare, WithStableTies())
+
+	SortTable(0, nil, nil)
+	SortTable(1, nil, nil)
 }
 
 func FuzzPartition(f *testing.F) {
@@ -163,6 +298,20 @@ func BenchmarkSortK(b *testing.B) {
 	SortFirst(list, 1_000)
 }
 
+func BenchmarkSortFirst(b *testing.B) {
+	src := floats(1_000_000)
+	list := make([]float64, len(src))
+	for _, k := range []int{1, 4, 16, 64, 1024, 16384, 262144,
+		500_000, 900_000, 990_000, 999_000, 1_000_000} {
+		b.Run(strconv.Itoa(k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortFirst(list, k)
+			}
+		})
+	}
+}
+
 func BenchmarkSelect(b *testing.B) {
 	list := floats(10_000_000)
 	b.ResetTimer()
@@ -0,0 +1,135 @@
+package quick
+
+import "cmp"
+
+// SortReverseView uses the Quicksort algorithm to sort a slice in descending order,
+// as if sorting a reversed view of it in ascending order:
+// the result is the same as Sort followed by slices.Reverse.
+// Comparisons are inverted as they're made, in pivot selection,
+// partitioning, and the base cases,
+// so there's no extra pass to reverse the slice.
+// It mirrors Sort, rather than use SortWith with WithAscending(false),
+// which inverts a comparison function, and is twice as slow.
+// Still, the pass it saves is cheap, next to the sort:
+// for 10 million ints, BenchmarkSortReverseView finds it
+// about 6% faster than Sort and slices.Reverse.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortReverseView[T cmp.Ordered](s []T) {
+	for len(s) > minLen {
+		p := partitionDesc(s)
+		if p > len(s)/2 {
+			SortReverseView(s[p:])
+			s = s[:p]
+		} else {
+			SortReverseView(s[:p])
+			s = s[p:]
+		}
+	}
+	insertionDesc(s)
+}
+
+// Greater is the inverted comparison.
+func greater[T cmp.Ordered](a, b T) bool {
+	return cmp.Less(b, a)
+}
+
+// PartitionDesc mirrors partition, with inverted comparisons.
+func partitionDesc[T cmp.Ordered](s []T) int {
+	r := len(s) - 1
+
+	if r >= minMed3 {
+		if greater(s[r], s[0]) {
+			s[0], s[r] = s[r], s[0]
+		}
+		if greater(s[r/2], s[0]) {
+			s[0], s[r/2] = s[r/2], s[0]
+		}
+		if greater(s[r], s[r/2]) {
+			s[r], s[r/2] = s[r/2], s[r]
+		}
+	}
+
+	p := s[r/2]
+	i := hoarePartitionDesc(s, p)
+
+	if r >= minMedMed {
+		b := r / minRatio
+		if !(b < i && i < r-b) {
+			p = medianOfMediansDesc(s)
+			i = hoarePartitionDesc(s, p)
+		}
+	}
+	return i
+}
+
+// HoarePartitionDesc mirrors hoarePartition, with inverted comparisons.
+func hoarePartitionDesc[T cmp.Ordered](s []T, p T) int {
+	r := len(s) - 1
+	i := 0
+	j := r
+	for {
+		for i < r && greater(s[i], p) {
+			i += 1
+		}
+		for j > 0 && greater(p, s[j]) {
+			j -= 1
+		}
+		if i > j {
+			return i
+		}
+		s[i], s[j] = s[j], s[i]
+		i += 1
+		j -= 1
+	}
+}
+
+// InsertionDesc mirrors insertion, with inverted comparisons.
+func insertionDesc[T cmp.Ordered](s []T) {
+	for i, p := range s {
+		for i > 0 && greater(p, s[i-1]) {
+			s[i] = s[i-1]
+			i -= 1
+		}
+		s[i] = p
+	}
+}
+
+// MedianOfMediansDesc mirrors medianOfMedians, with inverted comparisons.
+// The median is the same either way, but groups are sorted in descending order,
+// and the Median-of-medians is selected in it.
+func medianOfMediansDesc[T cmp.Ordered](s []T) T {
+	m := 0
+	for i := 0; i+5 < len(s); i += 5 {
+		insertionDesc(s[i : i+5])
+		s[m], s[i+2] = s[i+2], s[m]
+		m += 1
+	}
+	if m < 2 {
+		return s[0]
+	}
+	return selectDesc(s[:m], m/2)
+}
+
+// SelectDesc mirrors Select, with inverted comparisons.
+func selectDesc[T cmp.Ordered](s []T, k int) T {
+	for k >= minK {
+		p := partitionDesc(s)
+		if p > k {
+			s = s[:p]
+		} else {
+			s = s[p:]
+			k -= p
+		}
+	}
+	for i, p := range s[:k+1] {
+		m := 0
+		for j, q := range s[i:] {
+			if greater(q, p) {
+				m = j
+				p = q
+			}
+		}
+		s[i], s[m+i] = s[m+i], s[i]
+	}
+	return s[k]
+}
@@ -0,0 +1,58 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestSortReverseView(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+			slices.Reverse(want)
+
+			SortReverseView(tt.list)
+			if !slices.Equal(tt.list, want) {
+				t.FailNow()
+			}
+		})
+	}
+
+	floats := []float64{2, -1, 3, 0, 3}
+	SortReverseView(floats)
+	if !slices.IsSortedFunc(floats, func(a, b float64) int { return cmp.Compare(b, a) }) {
+		t.Errorf("got %v", floats)
+	}
+}
+
+func BenchmarkSortReverseView(b *testing.B) {
+	src := permutation(10_000_000)
+	list := make([]int, len(src))
+	b.Run("Sort+Reverse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			Sort(list)
+			slices.Reverse(list)
+		}
+	})
+	b.Run("SortReverseView", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortReverseView(list)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package quick
+
+import (
+	"cmp"
+	"unicode"
+)
+
+// SortRunesFold sorts a slice of runes, ignoring case,
+// as defined by Unicode simple case folding.
+// Runes that fold to the same are sorted by code point,
+// so upper and lower case letters stay together, in a deterministic order.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortRunesFold(s []rune) {
+	SortFunc(s, func(a, b rune) int {
+		if c := cmp.Compare(fold(a), fold(b)); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+}
+
+// Fold returns the smallest rune equivalent to r
+// under Unicode simple case folding.
+func fold(r rune) rune {
+	m := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		m = min(m, f)
+	}
+	return m
+}
@@ -0,0 +1,24 @@
+package quick
+
+import "testing"
+
+func TestSortRunesFold(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"bBaA", "AaBb"},
+		{"Hello, World!", " !,deHllloorW"},
+		{"zZaAmM", "AaMmZz"},
+		{"kKK", "KkK"},
+		{"σςΣ", "Σςσ"},
+		{"ÉéeE", "EeÉé"},
+	}
+	for _, tt := range tests {
+		s := []rune(tt.in)
+		SortRunesFold(s)
+		if got := string(s); got != tt.want {
+			t.Errorf("SortRunesFold(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+)
+
+const (
+	minRunLen = 256  // average length of runs worth merging
+	minMerge  = 4096 // at least 1
+)
+
+// A MergeStrategy is an algorithm to merge sorted runs.
+type MergeStrategy int
+
+const (
+	// LinearMerge merges runs in a single pass, the default.
+	LinearMerge MergeStrategy = iota
+	// RecursiveMerge merges runs by divide and conquer,
+	// which is cache-oblivious, at the cost of some binary searches.
+	// Whether it wins depends on the memory hierarchy:
+	// BenchmarkWithMergeStrategy compares both.
+	RecursiveMerge
+)
+
+// WithMergeStrategy selects the algorithm to merge sorted runs,
+// for sorts that merge them, like WithRunDetection.
+func WithMergeStrategy(m MergeStrategy) Option {
+	return func(c *config) { c.mergeStrategy = m }
+}
+
+// WithGallop makes merging runs switch to galloping, like Timsort,
+// once one run supplies minGallop elements in a row (Timsort uses 7).
+// Galloping finds how many more elements that run supplies,
+// by exponential search, and copies them at once.
+// When merging runs of very different lengths, or that barely interleave,
+// like concatenated sorted logs, this saves most comparisons;
+// when runs interleave finely, it costs a few extra.
+// A minGallop of 0 disables galloping, the default.
+func WithGallop(minGallop int) Option {
+	return func(c *config) { c.minGallop = max(minGallop, 0) }
+}
+
+// WithRunDetection makes SortFunc look for runs of ascending elements,
+// or strictly descending ones, which it reverses,
+// and, if there are few, long ones, merge them, rather than use Quicksort.
+// This suits data that arrives mostly sorted, like concatenated sorted batches,
+// or sorted data with a few elements out of place.
+// Detection gives up after finding too many runs,
+// so, for other data, it's cheap.
+// Merging uses O(n) extra space.
+func WithRunDetection() Option {
+	return func(c *config) { c.runDetection = true }
+}
+
+// Runs returns the number of maximal ascending runs in s,
+// a cheap measure of how presorted it is:
+// sorted slices have a single run, strictly descending ones have len(s).
+// It uses O(n) time and O(1) space.
+func Runs[T cmp.Ordered](s []T) int {
+	n := 0
+	for i := range s {
+		if i == 0 || cmp.Less(s[i], s[i-1]) {
+			n += 1
+		}
+	}
+	return n
+}
+
+// FewRuns reports if s has few enough maximal ascending runs,
+// at most limit, to be worth merging.
+// It gives up as soon as it finds more.
+// It uses O(n) time and O(1) space.
+func (q *sorter[T]) fewRuns(s []T, limit int) bool {
+	n := 0
+	for i := range s {
+		if i == 0 || q.less(s[i], s[i-1]) {
+			n += 1
+			if n > limit {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// WithReverseInput makes SortFunc check if s is descending,
+// and if so, simply reverse it.
+// Data that arrives newest-first, for instance, is sorted in O(n) time.
+// The check gives up at the first ascending pair, so, for other data, it's cheap.
+func WithReverseInput() Option {
+	return func(c *config) { c.reverseInput = true }
+}
+
+// SortReversed reverses s, if it's descending.
+// It reports whether it did.
+// It uses O(n) time and O(1) space.
+func (q *sorter[T]) sortReversed(s []T) bool {
+	for i := 1; i < len(s); i += 1 {
+		if q.less(s[i-1], s[i]) {
+			return false
+		}
+	}
+	slices.Reverse(s)
+	return true
+}
+
+// SortRuns sorts s by merging its ascending runs, if there are few enough.
+// It reports whether it did.
+// It uses O(n·log(r)) time and O(n) space, for r runs.
+func (q *sorter[T]) sortRuns(s []T) bool {
+	// Find where each run ends, giving up if they're too short on average.
+	// Strictly descending runs are reversed, which keeps equal elements in order.
+	limit := len(s) / minRunLen
+	var runs []int
+	for lo := 0; lo < len(s); {
+		hi := q.descRun(s, lo)
+		if hi > lo+1 {
+			slices.Reverse(s[lo:hi])
+		} else {
+			hi = q.ascRun(s, lo)
+		}
+		if hi < len(s) && len(runs) >= limit {
+			return false
+		}
+		runs = append(runs, hi)
+		lo = hi
+	}
+
+	// Merge adjacent pairs of runs, until a single one is left.
+	var buf []T
+	for len(runs) > 1 {
+		lo := 0
+		n := 0
+		for i := 0; i < len(runs); i += 2 {
+			hi := runs[i]
+			if i+1 < len(runs) {
+				hi = runs[i+1]
+				buf = q.merge(s[lo:hi], runs[i]-lo, buf)
+			}
+			runs[n] = hi
+			n += 1
+			lo = hi
+		}
+		runs = runs[:n]
+	}
+	return true
+}
+
+// AscRun returns where the ascending run that starts at s[lo] ends.
+func (q *sorter[T]) ascRun(s []T, lo int) int {
+	hi := lo + 1
+	for hi < len(s) && !q.less(s[hi], s[hi-1]) {
+		hi += 1
+	}
+	return hi
+}
+
+// DescRun returns where the strictly descending run that starts at s[lo] ends.
+func (q *sorter[T]) descRun(s []T, lo int) int {
+	hi := lo + 1
+	for hi < len(s) && q.less(s[hi], s[hi-1]) {
+		hi += 1
+	}
+	return hi
+}
+
+// Merge merges the sorted s[:mid] and s[mid:] into s,
+// keeping equal elements in order,
+// using buf to hold s[:mid], and returning it for reuse.
+func (q *sorter[T]) merge(s []T, mid int, buf []T) []T {
+	buf = append(buf[:0], s[:mid]...)
+	if q.mergeStrategy == RecursiveMerge {
+		q.mergeRecursive(s, buf, s[mid:])
+	} else {
+		q.mergeLinear(s, buf, s[mid:])
+	}
+	return buf
+}
+
+// MergeLinear merges the sorted a and b into dst,
+// taking elements of a first, when equal.
+// Elements of b may overlap the end of dst:
+// each is read before it's overwritten.
+// It uses O(n) time and O(1) space.
+func (q *sorter[T]) mergeLinear(dst, a, b []T) {
+	if q.minGallop > 0 {
+		q.mergeGallop(dst, a, b)
+		return
+	}
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if q.less(b[j], a[i]) {
+			dst[k] = b[j]
+			j += 1
+		} else {
+			dst[k] = a[i]
+			i += 1
+		}
+		k += 1
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// MergeGallop merges like mergeLinear,
+// but once a or b supplies minGallop elements in a row,
+// it gallops to find how many more it supplies.
+// It uses O(n) time and O(1) space.
+func (q *sorter[T]) mergeGallop(dst, a, b []T) {
+	i, j, k := 0, 0, 0
+	wa, wb := 0, 0 // elements in a row
+	for i < len(a) && j < len(b) {
+		if q.less(b[j], a[i]) {
+			dst[k] = b[j]
+			j += 1
+			k += 1
+			wa, wb = 0, wb+1
+			if wb >= q.minGallop {
+				// Elements of b less than a[i] go before it.
+				p := a[i]
+				n := q.gallop(b[j:], func(v T) bool { return !q.less(v, p) })
+				k += copy(dst[k:], b[j:j+n])
+				j += n
+				wb = 0
+			}
+		} else {
+			dst[k] = a[i]
+			i += 1
+			k += 1
+			wa, wb = wa+1, 0
+			if wa >= q.minGallop {
+				// Elements of a not greater than b[j] go before it.
+				p := b[j]
+				n := q.gallop(a[i:], func(v T) bool { return q.less(p, v) })
+				k += copy(dst[k:], a[i:i+n])
+				i += n
+				wa = 0
+			}
+		}
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// Gallop returns the first index of s for which f is true,
+// or len(s), if there's none, like search,
+// but probes indices 0, 2, 6, 14, … first,
+// so it's faster when the index is small.
+// It uses O(log(i)) time and O(1) space, for the returned index i.
+func (q *sorter[T]) gallop(s []T, f func(T) bool) int {
+	lo, hi := 0, 1
+	for hi <= len(s) && !f(s[hi-1]) {
+		lo = hi
+		hi = 2*hi + 1
+	}
+	hi = min(hi, len(s))
+	return lo + searchFunc(s[lo:hi], f)
+}
+
+// MergeRecursive merges like mergeLinear,
+// but splits the longer of a and b at its middle element,
+// finds where that goes in the other by binary search,
+// and merges what goes before and after it recursively.
+// Recursion makes it cache-oblivious:
+// subproblems eventually fit in each level of cache,
+// whatever their sizes.
+// It uses O(n) time and O(log(n)) space.
+func (q *sorter[T]) mergeRecursive(dst, a, b []T) {
+	// Subproblems are merged in order, left to right,
+	// so elements of b are read before they're overwritten.
+	if len(a)+len(b) <= minMerge {
+		q.mergeLinear(dst, a, b)
+		return
+	}
+	if len(a) >= len(b) {
+		// Elements of b equal to a[m] go after it.
+		m := len(a) / 2
+		p := a[m]
+		j := searchFunc(b, func(v T) bool { return !q.less(v, p) })
+		q.mergeRecursive(dst[:m+j], a[:m], b[:j])
+		dst[m+j] = p
+		q.mergeRecursive(dst[m+j+1:], a[m+1:], b[j:])
+	} else {
+		// Elements of a equal to b[m] go before it.
+		m := len(b) / 2
+		p := b[m]
+		j := searchFunc(a, func(v T) bool { return q.less(p, v) })
+		q.mergeRecursive(dst[:j+m], a[:j], b[:m])
+		dst[j+m] = p
+		q.mergeRecursive(dst[j+m+1:], a[j:], b[m+1:])
+	}
+}
+
+// SearchFunc returns the first index of s for which f is true,
+// or len(s), if there's none, like sort.Search.
+// It uses O(log(n)) time and O(1) space.
+func searchFunc[T any](s []T, f func(T) bool) int {
+	lo, hi := 0, len(s)
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		if f(s[m]) {
+			hi = m
+		} else {
+			lo = m + 1
+		}
+	}
+	return lo
+}
@@ -0,0 +1,307 @@
+package quick
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestWithRunDetection(t *testing.T) {
+	tests := []struct {
+		name   string
+		list   []int
+		merged bool
+	}{
+		{"empty", nil, true},
+		{"zeros", zeros(100_000), true},
+		{"bits", bits(100_000), false},
+		{"sorted", sorted(100_000), true},
+		{"reversed", reversed(100_000), true},
+		{"pipeorgan", pipeorgan(100_000), true},
+		{"permutation", permutation(100_000), false},
+		{"killer", killer(128*1024 - 1), false},
+		{"batches", batches(100_000, 100), true},
+		{"spikes", spikes(100_000, 100), true},
+		{"short", batches(100_000, 10_000), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Profile
+			SortFunc(tt.list, cmp.Compare, WithRunDetection(), WithProfile(&p))
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			if merged := p.Partitions == 0; merged != tt.merged {
+				t.Errorf("merged = %v, want %v", merged, tt.merged)
+			}
+		})
+	}
+}
+
+func TestWithRunDetection_stable(t *testing.T) {
+	list := make([]record, 100_000)
+	for i := range list {
+		list[i] = record{(i % 1000) / 10, i}
+	}
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byKey)
+
+	SortFunc(list, byKey, WithRunDetection())
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+}
+
+func TestRuns(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+		want int
+	}{
+		{"empty", nil, 0},
+		{"zeros", zeros(100_000), 1},
+		{"sorted", sorted(100_000), 1},
+		{"reversed", reversed(100_000), 100_000},
+		{"pipeorgan", pipeorgan(100_000), 50_000},
+		{"batches", batches(100_000, 100), 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Runs(tt.list); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithAdaptivePartition_runs(t *testing.T) {
+	tests := []struct {
+		name   string
+		list   []int
+		merged bool
+	}{
+		{"sorted", sorted(100_000), true},
+		{"batches", batches(100_000, 100), true},
+		{"spikes", spikes(100_000, 100), true},
+		{"reversed", reversed(100_000), false},
+		{"short", batches(100_000, 10_000), false},
+		{"permutation", permutation(100_000), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Profile
+			SortFunc(tt.list, cmp.Compare, WithAdaptivePartition(), WithProfile(&p))
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			if merged := p.Partitions == 0; merged != tt.merged {
+				t.Errorf("merged = %v, want %v", merged, tt.merged)
+			}
+		})
+	}
+}
+
+func TestWithReverseInput(t *testing.T) {
+	swapped := reversed(100_000)
+	swapped[500], swapped[501] = swapped[501], swapped[500]
+
+	tests := []struct {
+		name     string
+		list     []int
+		reversed bool
+	}{
+		{"empty", nil, true},
+		{"zeros", zeros(100_000), true},
+		{"reversed", reversed(100_000), true},
+		{"sorted", sorted(100_000), false},
+		{"pipeorgan", pipeorgan(100_000), false},
+		{"swapped", swapped, false},
+		{"last", append(reversed(100_000), 100_000), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Profile
+			SortFunc(tt.list, cmp.Compare, WithReverseInput(), WithProfile(&p))
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			if reversed := p.Partitions == 0; reversed != tt.reversed {
+				t.Errorf("reversed = %v, want %v", reversed, tt.reversed)
+			}
+		})
+	}
+}
+
+func BenchmarkWithReverseInput(b *testing.B) {
+	src := reversed(1_000_000)
+	list := make([]int, len(src))
+	for _, rev := range []bool{false, true} {
+		b.Run(strconv.FormatBool(rev), func(b *testing.B) {
+			var opts []Option
+			if rev {
+				opts = append(opts, WithReverseInput())
+			}
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortFunc(list, cmp.Compare, opts...)
+			}
+		})
+	}
+}
+
+func TestWithMergeStrategy(t *testing.T) {
+	for _, m := range []MergeStrategy{LinearMerge, RecursiveMerge} {
+		for _, k := range []int{1, 2, 3, 10, 100} {
+			list := make([]record, 100_000)
+			for i, v := range batches(len(list), k) {
+				list[i] = record{v / 100, i}
+			}
+			want := slices.Clone(list)
+			slices.SortStableFunc(want, byKey)
+
+			SortFunc(list, byKey, WithRunDetection(), WithMergeStrategy(m))
+			if !slices.Equal(list, want) {
+				t.Fatalf("strategy %d, %d batches: not sorted, or not stable", m, k)
+			}
+		}
+
+		// Merge runs of random lengths directly.
+		q := newSorter(byKey, []Option{WithMergeStrategy(m)})
+		for i := 0; i < 100; i += 1 {
+			n := rand.Intn(10_000)
+			mid := rand.Intn(n + 1)
+			list := records(n, 100)
+			slices.SortStableFunc(list[:mid], byKey)
+			slices.SortStableFunc(list[mid:], byKey)
+			want := slices.Clone(list)
+			slices.SortStableFunc(want, byKey)
+
+			q.merge(list, mid, nil)
+			if !slices.Equal(list, want) {
+				t.Fatalf("strategy %d, merge(%d, %d): not sorted, or not stable", m, n, mid)
+			}
+		}
+	}
+}
+
+func TestWithGallop(t *testing.T) {
+	for _, g := range []int{1, 2, 7} {
+		for _, k := range []int{1, 2, 3, 10, 100} {
+			list := make([]record, 100_000)
+			for i, v := range batches(len(list), k) {
+				list[i] = record{v / 100, i}
+			}
+			want := slices.Clone(list)
+			slices.SortStableFunc(want, byKey)
+
+			SortFunc(list, byKey, WithRunDetection(), WithGallop(g))
+			if !slices.Equal(list, want) {
+				t.Fatalf("gallop %d, %d batches: not sorted, or not stable", g, k)
+			}
+		}
+
+		// Merge runs of random lengths and structure directly.
+		q := newSorter(byKey, []Option{WithGallop(g)})
+		for i := 0; i < 100; i += 1 {
+			n := rand.Intn(10_000)
+			mid := rand.Intn(n + 1)
+			list := records(n, 1+rand.Intn(n+1))
+			// Some runs barely interleave.
+			if i%2 == 0 {
+				slices.SortStableFunc(list, byKey)
+				slices.Reverse(list[:mid])
+				slices.Reverse(list[mid:])
+				slices.Reverse(list)
+			}
+			slices.SortStableFunc(list[:mid], byKey)
+			slices.SortStableFunc(list[mid:], byKey)
+			want := slices.Clone(list)
+			slices.SortStableFunc(want, byKey)
+
+			q.merge(list, mid, nil)
+			if !slices.Equal(list, want) {
+				t.Fatalf("gallop %d, merge(%d, %d): not sorted, or not stable", g, n, mid)
+			}
+		}
+	}
+}
+
+func BenchmarkWithGallop(b *testing.B) {
+	for _, tt := range []struct {
+		name string
+		list []int
+	}{
+		{"batches", batches(1_000_000, 16)},
+		{"spikes", spikes(1_000_000, 16)},
+	} {
+		list := make([]int, len(tt.list))
+		for _, g := range []int{0, 7} {
+			b.Run(tt.name+"/"+strconv.Itoa(g), func(b *testing.B) {
+				var p Profile
+				for i := 0; i < b.N; i++ {
+					copy(list, tt.list)
+					SortFunc(list, cmp.Compare, WithRunDetection(), WithGallop(g), WithProfile(&p))
+				}
+				b.ReportMetric(float64(p.Comparisons)/float64(b.N), "cmps/op")
+			})
+		}
+	}
+}
+
+func BenchmarkWithMergeStrategy(b *testing.B) {
+	src := batches(10_000_000, 64)
+	list := make([]int, len(src))
+	for _, m := range []MergeStrategy{LinearMerge, RecursiveMerge} {
+		b.Run(strconv.Itoa(int(m)), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortFunc(list, cmp.Compare, WithRunDetection(), WithMergeStrategy(m))
+			}
+		})
+	}
+}
+
+func BenchmarkWithRunDetection(b *testing.B) {
+	for _, tt := range []struct {
+		name string
+		list []int
+	}{
+		{"batches", batches(1_000_000, 16)},
+		{"spikes", spikes(1_000_000, 16)},
+		{"permutation", permutation(1_000_000)},
+	} {
+		list := make([]int, len(tt.list))
+		for _, runs := range []bool{false, true} {
+			b.Run(tt.name+"/"+strconv.FormatBool(runs), func(b *testing.B) {
+				var opts []Option
+				if runs {
+					opts = append(opts, WithRunDetection())
+				}
+				for i := 0; i < b.N; i++ {
+					copy(list, tt.list)
+					SortFunc(list, cmp.Compare, opts...)
+				}
+			})
+		}
+	}
+}
+
+// Batches concatenates k sorted random batches.
+func batches(n, k int) []int {
+	s := permutation(n)
+	for i := 0; i < k; i += 1 {
+		slices.Sort(s[i*n/k : (i+1)*n/k])
+	}
+	return s
+}
+
+// Spikes puts k random elements in an otherwise sorted slice.
+func spikes(n, k int) []int {
+	s := sorted(n)
+	for i := 0; i < k; i += 1 {
+		s[rand.Intn(n)] = rand.Int()
+	}
+	return s
+}
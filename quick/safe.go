@@ -0,0 +1,29 @@
+package quick
+
+import (
+	"context"
+	"fmt"
+)
+
+// SortFuncSafe uses the Quicksort algorithm to sort a slice,
+// as determined by the cmp function.
+// If cmp panics, SortFuncSafe recovers,
+// and returns an error with the panic value and the elements being compared.
+// It then leaves the slice partially sorted,
+// but still holding the same elements, like SortFuncContext.
+// Recovering costs a deferred call per comparison.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortFuncSafe[T any](s []T, cmp func(a, b T) int) error {
+	return SortFuncContext(context.Background(), s, func(_ context.Context, a, b T) (c int, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				if e, ok := r.(error); ok {
+					err = fmt.Errorf("quick: comparison function panicked on (%v, %v): %w", a, b, e)
+				} else {
+					err = fmt.Errorf("quick: comparison function panicked on (%v, %v): %v", a, b, r)
+				}
+			}
+		}()
+		return cmp(a, b), nil
+	})
+}
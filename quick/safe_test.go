@@ -0,0 +1,43 @@
+package quick
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortFuncSafe(t *testing.T) {
+	list := permutation(100_000)
+	if err := SortFuncSafe(list, cmp.Compare[int]); err != nil || !slices.IsSorted(list) {
+		t.Fatalf("got %v", err)
+	}
+
+	list = permutation(100_000)
+	err := SortFuncSafe(list, func(a, b int) int {
+		if a == 42 && b == 43 || a == 43 && b == 42 {
+			panic("sentinel")
+		}
+		return cmp.Compare(a, b)
+	})
+	if err == nil || !strings.Contains(err.Error(), "sentinel") || !strings.Contains(err.Error(), "42") {
+		t.Fatalf("got %v", err)
+	}
+	slices.Sort(list)
+	if !slices.Equal(list, sorted(len(list))) {
+		t.Fatal("lost elements")
+	}
+
+	errSentinel := errors.New("sentinel")
+	list = permutation(100_000)
+	err = SortFuncSafe(list, func(a, b int) int {
+		if a == 7 {
+			panic(errSentinel)
+		}
+		return cmp.Compare(a, b)
+	})
+	if !errors.Is(err, errSentinel) {
+		t.Fatalf("got %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package quick
+
+import (
+	"cmp"
+	"runtime"
+	"sync/atomic"
+)
+
+const oversample = 16 // samples per splitter
+
+// SortSample uses the Sample sort algorithm to sort a slice, in parallel.
+// It picks splitters from a sorted sample,
+// scatters the elements into the buckets between them,
+// in parallel, like SelectParallel,
+// then sorts the buckets concurrently with Sort.
+// Elements equal to a splitter get buckets of their own, which need no sorting,
+// so heavily duplicated elements don't unbalance the work.
+// It uses O(n·log(n)) time and O(n) space.
+func SortSample[T cmp.Ordered](s []T) {
+	p := runtime.GOMAXPROCS(0)
+	if p < 2 || len(s) < 2*minParallel {
+		Sort(s)
+		return
+	}
+
+	// Pick splitters, with a few buckets per CPU to balance the work.
+	b := min(4*p, len(s)/minParallel)
+	sample := make([]T, b*oversample)
+	for i := range sample {
+		sample[i] = s[i*(len(s)-1)/(len(sample)-1)]
+	}
+	Sort(sample)
+	splitters := make([]T, b-1)
+	for i := range splitters {
+		splitters[i] = sample[(i+1)*oversample]
+	}
+
+	// Bucket 2i holds elements between splitters i-1 and i,
+	// bucket 2i-1 elements equal to splitter i-1.
+	bucket := func(v T) int {
+		lo := searchFunc(splitters, func(x T) bool { return cmp.Less(v, x) })
+		if lo > 0 && !cmp.Less(splitters[lo-1], v) {
+			return 2*lo - 1
+		}
+		return 2 * lo
+	}
+	nb := 2*b - 1
+
+	chunk := func(c int) []T {
+		return s[c*len(s)/p : (c+1)*len(s)/p]
+	}
+
+	// Count the elements of each chunk in each bucket.
+	count := make([][]int, p)
+	parallel(p, func(c int) {
+		cnt := make([]int, nb)
+		for _, v := range chunk(c) {
+			cnt[bucket(v)] += 1
+		}
+		count[c] = cnt
+	})
+
+	// Turn counts into offsets into buf.
+	start := make([]int, nb+1)
+	var off int
+	for i := 0; i < nb; i += 1 {
+		start[i] = off
+		for c := range count {
+			n := count[c][i]
+			count[c][i] = off
+			off += n
+		}
+	}
+	start[nb] = off
+
+	buf := make([]T, len(s))
+	parallel(p, func(c int) {
+		off := count[c]
+		for _, v := range chunk(c) {
+			i := bucket(v)
+			buf[off[i]] = v
+			off[i] += 1
+		}
+	})
+	parallel(p, func(c int) {
+		lo := c * len(s) / p
+		copy(chunk(c), buf[lo:])
+	})
+
+	// Sort the buckets, handing them out to goroutines as they finish others.
+	var next atomic.Int64
+	parallel(p, func(int) {
+		for {
+			i := int(next.Add(1) - 1)
+			if i >= nb {
+				return
+			}
+			if i%2 == 0 {
+				Sort(s[start[i]:start[i+1]])
+			}
+		}
+	})
+}
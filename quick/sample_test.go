@@ -0,0 +1,63 @@
+package quick
+
+import (
+	"flag"
+	"runtime"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+var sampleSize = flag.Int("sample.n", 10_000_000, "elements to sort in BenchmarkSortSample (try 100_000_000)")
+
+func TestSortSample(t *testing.T) {
+	// Sort in parallel even if there's a single processor.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	// Large enough for several buckets of minParallel elements.
+	skewed := permutation(400_000)
+	for i := range skewed {
+		if i%4 != 0 {
+			skewed[i] = 42
+		}
+	}
+
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"small", permutation(1000)},
+		{"zeros", zeros(400_000)},
+		{"bits", bits(400_000)},
+		{"sorted", sorted(400_000)},
+		{"reversed", reversed(400_000)},
+		{"pipeorgan", pipeorgan(400_000)},
+		{"permutation", permutation(400_000)},
+		{"killer", killer(512*1024 - 1)},
+		{"skewed", skewed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			Sort(want)
+			SortSample(tt.list)
+			if !slices.Equal(tt.list, want) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func BenchmarkSortSample(b *testing.B) {
+	src := floats(*sampleSize)
+	list := make([]float64, len(src))
+	for _, p := range []int{1, 2, 4, 8} {
+		b.Run(strconv.Itoa(p), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(p))
+			for i := 0; i < b.N; i++ {
+				copy(list, src)
+				SortSample(list)
+			}
+		})
+	}
+}
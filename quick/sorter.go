@@ -0,0 +1,99 @@
+package quick
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// Stats are cumulative statistics about the sorts done by a Sorter.
+type Stats struct {
+	Sorts       int // number of sorts
+	Elements    int // number of elements sorted
+	Comparisons int // number of comparisons
+	Swaps       int // number of swaps, or equivalent shifts
+	Fallbacks   int // bad pivots replaced by Median-of-medians
+}
+
+// Add adds the statistics in o to s.
+func (s *Stats) add(o Stats) {
+	s.Sorts += o.Sorts
+	s.Elements += o.Elements
+	s.Comparisons += o.Comparisons
+	s.Swaps += o.Swaps
+	s.Fallbacks += o.Fallbacks
+}
+
+// A Sorter sorts slices with a given comparison function and options,
+// and keeps statistics about all the sorts it does.
+// Long running programs can monitor those,
+// for instance, to spot adversarial inputs
+// by a growing rate of fallbacks.
+// A Sorter is safe for concurrent use:
+// each sort gets its own pseudo-random generator, seeded from that of WithRand,
+// and the Profile of WithProfile is updated under a lock.
+// Callbacks given through options, like WithProgress,
+// are called by concurrent sorts concurrently.
+type Sorter[T any] struct {
+	cmp    func(a, b T) int
+	config config
+	mu     sync.Mutex
+	stats  Stats
+}
+
+// NewSorter returns a Sorter that sorts as determined by the cmp function,
+// configured by opts.
+func NewSorter[T any](cmp func(a, b T) int, opts ...Option) *Sorter[T] {
+	return &Sorter[T]{cmp: cmp, config: newConfig(opts)}
+}
+
+// Sort sorts s, like SortFunc, and returns statistics about this sort.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func (x *Sorter[T]) Sort(s []T) Stats {
+	var p Profile
+	sortConfig(s, x.cmp, x.sortConfig(&p))
+	return x.record(p, len(s))
+}
+
+// SortConfig is the configuration for a single sort, profiled by p.
+func (x *Sorter[T]) sortConfig(p *Profile) config {
+	c := x.config
+	c.profile = p
+	if c.rand != nil {
+		x.mu.Lock()
+		c.rand = rand.New(rand.NewSource(c.rand.Int63()))
+		x.mu.Unlock()
+	}
+	return c
+}
+
+// Record adds a sort of n elements, profiled by p, to the statistics,
+// and returns those of this sort.
+func (x *Sorter[T]) record(p Profile, n int) Stats {
+	stats := Stats{
+		Sorts:       1,
+		Elements:    n,
+		Comparisons: p.Comparisons,
+		Swaps:       p.Swaps,
+		Fallbacks:   p.Fallbacks,
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.stats.add(stats)
+
+	// A Profile given through WithProfile still gets its statistics.
+	if u := x.config.profile; u != nil {
+		u.MaxDepth = max(u.MaxDepth, p.MaxDepth)
+		u.Partitions += p.Partitions
+		u.Fallbacks += p.Fallbacks
+		u.Comparisons += p.Comparisons
+		u.Swaps += p.Swaps
+	}
+	return stats
+}
+
+// Stats returns the cumulative statistics of all sorts done so far.
+func (x *Sorter[T]) Stats() Stats {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return x.stats
+}
@@ -0,0 +1,78 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSorter(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+
+	var sum Stats
+	x := NewSorter(cmp.Compare[int])
+	for _, tt := range tests {
+		stats := x.Sort(tt.list)
+		if !slices.IsSorted(tt.list) {
+			t.Fatalf("%s: not sorted", tt.name)
+		}
+		if stats.Elements != len(tt.list) || stats.Comparisons == 0 {
+			t.Fatalf("%s: got %+v", tt.name, stats)
+		}
+		sum.add(stats)
+	}
+
+	if got := x.Stats(); got != sum {
+		t.Errorf("got %+v, want %+v", got, sum)
+	}
+	if sum.Sorts != len(tests) || sum.Fallbacks == 0 || sum.Swaps == 0 {
+		t.Errorf("got %+v", sum)
+	}
+}
+
+func TestSorter_comparisons(t *testing.T) {
+	var n int
+	x := NewSorter(func(a, b int) int {
+		n += 1
+		return cmp.Compare(a, b)
+	})
+	stats := x.Sort(permutation(100_000))
+	if stats.Comparisons != n {
+		t.Errorf("got %d comparisons, want %d", stats.Comparisons, n)
+	}
+}
+
+func TestSorter_concurrent(t *testing.T) {
+	var p Profile
+	x := NewSorter(cmp.Compare[int], WithProfile(&p), WithRand(42))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			list := permutation(10_000)
+			x.Sort(list)
+			if !slices.IsSorted(list) {
+				t.Error("not sorted")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := x.Stats(); got.Sorts != 16 || got.Comparisons != p.Comparisons {
+		t.Errorf("got %+v, and %+v", got, p)
+	}
+}
@@ -0,0 +1,67 @@
+package quick
+
+import (
+	"cmp"
+	"math/rand"
+)
+
+const splitterSample = 256 // samples per part
+
+// Splitters returns parts-1 ascending splitter values, approximate quantiles of s,
+// which range partition s into parts of about the same size:
+// for instance, to spread the data across parts workers.
+// The splitters are selected, with Select, from a reservoir sample of s,
+// which isn't modified.
+// The sample has 256 elements per part; with m samples per part,
+// part sizes are typically off by a factor of about 1/√m (6% for 256),
+// so more parts need a larger sample, while more data doesn't.
+// The same input always gets the same splitters.
+// If s has fewer elements than parts, some splitters repeat.
+// It panics if parts < 1, and returns nil if s is empty.
+// It uses O(n) time and O(parts) space.
+func Splitters[T cmp.Ordered](s []T, parts int) []T {
+	if parts < 1 {
+		panic("quick: Splitters needs at least 1 part")
+	}
+	if len(s) == 0 {
+		return nil
+	}
+
+	// Algorithm R: each element has the same chance of being sampled.
+	rnd := rand.New(rand.NewSource(1))
+	sample := make([]T, min(len(s), parts*splitterSample))
+	copy(sample, s)
+	for i := len(sample); i < len(s); i += 1 {
+		if j := rnd.Intn(i + 1); j < len(sample) {
+			sample[j] = s[i]
+		}
+	}
+
+	splitters := make([]T, parts-1)
+	selectSplitters(sample, splitters, 0, parts)
+	return splitters
+}
+
+// SelectSplitters selects splitters lo+1 through hi-1 of parts from s,
+// the sample of those parts, into dst[lo:hi-1].
+// Selecting the middle splitter first, then recursing,
+// partitions s around it, so each half can be searched separately.
+// Only a left half can be empty, and then its splitters repeat the one after it,
+// which is already selected.
+// It uses O(n·log(parts)) time and O(log(parts)) space.
+func selectSplitters[T cmp.Ordered](s, dst []T, lo, hi int) {
+	if hi-lo < 2 {
+		return
+	}
+	if len(s) == 0 {
+		for i := lo; i < hi-1; i += 1 {
+			dst[i] = dst[hi-1]
+		}
+		return
+	}
+	m := int(uint(lo+hi) >> 1)
+	k := len(s) * (m - lo) / (hi - lo)
+	dst[m-1] = Select(s, k)
+	selectSplitters(s[:k], dst, lo, m)
+	selectSplitters(s[k:], dst, m, hi)
+}
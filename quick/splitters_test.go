@@ -0,0 +1,57 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitters(t *testing.T) {
+	list := permutation(100_000)
+	orig := slices.Clone(list)
+
+	for _, parts := range []int{1, 2, 7, 16, 100} {
+		splitters := Splitters(list, parts)
+		if len(splitters) != parts-1 {
+			t.Fatalf("parts=%d: got %d splitters", parts, len(splitters))
+		}
+		if !slices.IsSorted(splitters) {
+			t.Fatalf("parts=%d: splitters not ascending", parts)
+		}
+
+		// With a permutation, a splitter's value is its rank.
+		want := len(list) / parts
+		prev := 0
+		for _, v := range append(splitters, len(list)) {
+			if size := v - prev; size < want*3/4 || size > want*5/4 {
+				t.Fatalf("parts=%d: got part of %d, want about %d", parts, size, want)
+			}
+			prev = v
+		}
+	}
+
+	if !slices.Equal(list, orig) {
+		t.Error("input modified")
+	}
+	if got := Splitters(list[:10], 4); len(got) != 3 || !slices.IsSorted(got) {
+		t.Errorf("got %v", got)
+	}
+	if got := Splitters([]int{}, 4); got != nil {
+		t.Errorf("got %v", got)
+	}
+
+	// Fewer elements than parts.
+	if got := Splitters([]int{5}, 4); !slices.Equal(got, []int{5, 5, 5}) {
+		t.Errorf("got %v", got)
+	}
+	if got := Splitters([]int{3, 1, 2}, 8); len(got) != 7 || !slices.IsSorted(got) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func BenchmarkSplitters(b *testing.B) {
+	list := permutation(10_000_000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Splitters(list, 64)
+	}
+}
@@ -0,0 +1,117 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SortStableFunc uses the Quicksort algorithm to sort a slice,
+// as determined by the cmp function,
+// keeping equal elements in their original order.
+// Quicksort isn't stable, so this tags elements with their original index,
+// and uses it to break ties.
+// It uses O(n·log(n)) time and O(n) space.
+func SortStableFunc[T any](s []T, cmp func(a, b T) int) {
+	x := tag(s)
+	newSorter(byIndex(cmp), nil).sort(x)
+	untag(s, x)
+}
+
+// SortStableDescending uses the Quicksort algorithm to sort a slice in descending order,
+// keeping equal elements in their original order.
+// It uses O(n·log(n)) time and O(n) space.
+func SortStableDescending[T cmp.Ordered](s []T) {
+	SortStableDescendingFunc(s, cmp.Compare[T])
+}
+
+// SortStableDescendingFunc sorts a slice like SortStableFunc,
+// but in descending order, as determined by the cmp function.
+// Only the comparison is reversed:
+// ties are still broken by ascending original index,
+// so equal elements keep their original order.
+// It uses O(n·log(n)) time and O(n) space.
+func SortStableDescendingFunc[T any](s []T, cmp func(a, b T) int) {
+	SortStableFunc(s, Reverse(cmp))
+}
+
+// SortStableCompactFunc sorts a slice like SortStableFunc,
+// then removes all but the first of each run of equal elements,
+// returning the compacted slice.
+// Since the sort is stable, the first occurrence of each element wins.
+// It uses O(n·log(n)) time and O(n) space.
+func SortStableCompactFunc[T any](s []T, cmp func(a, b T) int) []T {
+	SortStableFunc(s, cmp)
+	return slices.CompactFunc(s, func(a, b T) bool {
+		return cmp(a, b) == 0
+	})
+}
+
+// WithDeterministicOrder makes SortFunc keep equal elements in their original order,
+// which makes the result the same for any pivot selection, or parallelism.
+// This makes failures in fuzz tests of comparison functions reproducible.
+// It uses O(n) extra space.
+func WithDeterministicOrder() Option {
+	return func(c *config) { c.deterministicOrder = true }
+}
+
+// SortOrdered sorts elements tagged with their original index,
+// using all other options.
+// It's a function, rather than a method,
+// so sorter[T] doesn't depend on sorter[indexed[T]].
+func sortOrdered[T any](s []T, q *sorter[T]) {
+	c := q.config
+	c.deterministicOrder = false
+	c.descending = false // already in q.cmp
+	c.progress = nil     // reported below
+	c.verify = false     // done below
+
+	x := tag(s)
+	t := configSorter(byIndex(q.cmp), c)
+	if q.progress != nil {
+		t.prog = newProgress(q.progress, len(s))
+	}
+	t.sortAll(x)
+	untag(s, x)
+
+	if q.verify {
+		q.verifySorted(s)
+	}
+}
+
+// SelectStableFunc uses the Quickselect algorithm to find element k of the slice,
+// as determined by the cmp function,
+// partially sorting the slice around, and returning, s[k].
+// Among the elements that compare equal to s[k],
+// the one that ends up at index k is the one with the smallest original index,
+// as with WithStableTies.
+// It uses O(n) time and O(n) space.
+func SelectStableFunc[T any](s []T, k int, cmp func(a, b T) int) T {
+	return SelectFunc(s, k, cmp, WithStableTies())
+}
+
+// SortStableGuaranteed uses a bottom-up Merge sort to sort a slice,
+// keeping equal elements in their original order.
+// Runs of up to minLen elements are sorted with Insertion sort,
+// then merged in passes of doubling width.
+// Unlike Quicksort, it doesn't depend on pivots,
+// so no input can slow it down: it's a safe default for untrusted input.
+// It uses O(n·log(n)) time and O(n) space.
+func SortStableGuaranteed[T cmp.Ordered](s []T) {
+	q := &sorter[T]{cmp: cmp.Compare[T]}
+	q.sortMerge(s)
+}
+
+// SortMerge is a bottom-up Merge sort.
+// It uses O(n·log(n)) time and O(n) space.
+func (q *sorter[T]) sortMerge(s []T) {
+	for lo := 0; lo < len(s); lo += minLen {
+		q.insertion(s[lo:min(lo+minLen, len(s))])
+	}
+
+	buf := make([]T, 0, len(s)/2)
+	for w := minLen; w < len(s); w *= 2 {
+		for lo := 0; lo+w < len(s); lo += 2 * w {
+			buf = q.merge(s[lo:min(lo+2*w, len(s))], w, buf)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package quick
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"testing"
+)
+
+type record struct{ key, id int }
+
+func byKey(a, b record) int { return cmp.Compare(a.key, b.key) }
+
+func records(n, keys int) []record {
+	s := make([]record, n)
+	for i, k := range permutation(n) {
+		s[i] = record{k % keys, i}
+	}
+	return s
+}
+
+func TestSortStableFunc(t *testing.T) {
+	list := records(100_000, 1000)
+
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byKey)
+
+	SortStableFunc(list, byKey)
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+}
+
+func TestSortStableCompactFunc(t *testing.T) {
+	list := records(100_000, 1000)
+
+	first := map[int]int{}
+	for _, r := range list {
+		if _, ok := first[r.key]; !ok {
+			first[r.key] = r.id
+		}
+	}
+
+	list = SortStableCompactFunc(list, byKey)
+	if len(list) != len(first) || !slices.IsSortedFunc(list, byKey) {
+		t.FailNow()
+	}
+	for _, r := range list {
+		if first[r.key] != r.id {
+			t.Fatalf("key %d: got id %d, want %d", r.key, r.id, first[r.key])
+		}
+	}
+}
+
+func TestSortStableDescendingFunc(t *testing.T) {
+	list := records(100_000, 100)
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, func(a, b record) int { return cmp.Compare(b.key, a.key) })
+
+	SortStableDescendingFunc(list, byKey)
+	if !slices.Equal(list, want) {
+		t.FailNow()
+	}
+	for i := 1; i < len(list); i++ {
+		if list[i-1].key == list[i].key && list[i-1].id > list[i].id {
+			t.Fatalf("ties out of order at %d", i)
+		}
+	}
+
+	ints := bits(100_000)
+	SortStableDescending(ints)
+	if !slices.IsSortedFunc(ints, func(a, b int) int { return cmp.Compare(b, a) }) {
+		t.FailNow()
+	}
+}
+
+func FuzzWithDeterministicOrder(f *testing.F) {
+	f.Add([]byte("the quick brown fox jumps over the lazy dog"))
+	f.Add(make([]byte, 100))
+
+	f.Fuzz(func(t *testing.T, keys []byte) {
+		list := make([]record, len(keys))
+		for i, k := range keys {
+			list[i] = record{int(k % 8), i}
+		}
+		want := slices.Clone(list)
+		slices.SortStableFunc(want, byKey)
+
+		for _, opts := range [][]Option{
+			{WithDeterministicOrder()},
+			{WithDeterministicOrder(), WithRand(int64(len(keys)))},
+			{WithDeterministicOrder(), WithParallel(), WithParallelThreshold(0)},
+		} {
+			got := slices.Clone(list)
+			SortFunc(got, byKey, opts...)
+			if !slices.Equal(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		}
+	})
+}
+
+func TestSelectStableFunc(t *testing.T) {
+	list := records(100_000, 100)
+	for _, k := range []int{0, 999, 1000, 50_000, len(list) - 1} {
+		s := slices.Clone(list)
+		sel := SelectStableFunc(s, k, byKey)
+
+		first := len(list)
+		for _, r := range list {
+			if r.key == sel.key {
+				first = min(first, r.id)
+			}
+		}
+		ref := slices.Clone(list)
+		slices.SortFunc(ref, byKey)
+		if sel.key != ref[k].key || sel.id != first || s[k] != sel {
+			t.Fatalf("k=%d: got %v, want key %d, id %d", k, sel, ref[k].key, first)
+		}
+	}
+}
+
+func TestSortStableGuaranteed(t *testing.T) {
+	list := records(100_000, 1000)
+	want := slices.Clone(list)
+	slices.SortStableFunc(want, byKey)
+
+	// SortStableGuaranteed is sortMerge for ordered types,
+	// so check stability through records with duplicate keys.
+	(&sorter[record]{cmp: byKey}).sortMerge(list)
+	if !slices.Equal(list, want) {
+		t.Fatal("not sorted, or not stable")
+	}
+
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var compares int
+			q := &sorter[int]{cmp: func(a, b int) int {
+				compares += 1
+				return cmp.Compare(a, b)
+			}}
+			q.sortMerge(tt.list)
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			// Insertion sort takes at most minLen/2 comparisons per element,
+			// each merge pass at most one.
+			if n := len(tt.list); compares > n*minLen/2+n*int(math.Log2(float64(n))) {
+				t.Errorf("got %d comparisons", compares)
+			}
+		})
+	}
+}
+
+func BenchmarkSortStableGuaranteed(b *testing.B) {
+	src := permutation(1_000_000)
+	list := make([]int, len(src))
+
+	b.Run("SortStableGuaranteed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortStableGuaranteed(list)
+		}
+	})
+	b.Run("SortStableFunc", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortStableFunc(list, cmp.Compare[int])
+		}
+	})
+}
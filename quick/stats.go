@@ -0,0 +1,27 @@
+package quick
+
+import "cmp"
+
+// SelectStats are statistics about a selection.
+type SelectStats struct {
+	Partitions  int // number of partitions
+	Fallbacks   int // bad pivots replaced by Median-of-medians
+	Comparisons int // number of comparisons
+}
+
+// SelectProfiled uses the Quickselect algorithm to find element k of the slice,
+// partially sorting the slice around, and returning, s[k],
+// along with statistics about the selection.
+// It works like Select, but through SelectFunc,
+// which leaves Select free of profiling overhead.
+// It uses O(n) time and O(log(n)) space.
+func SelectProfiled[T cmp.Ordered](s []T, k int) (value T, stats SelectStats) {
+	var p Profile
+	value = SelectFunc(s, k, func(a, b T) int {
+		stats.Comparisons += 1
+		return cmp.Compare(a, b)
+	}, WithProfile(&p))
+	stats.Partitions = p.Partitions
+	stats.Fallbacks = p.Fallbacks
+	return value, stats
+}
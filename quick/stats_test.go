@@ -0,0 +1,27 @@
+package quick
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSelectProfiled(t *testing.T) {
+	list := killer(128*1024 - 1)
+	k := len(list) / 2
+	sel, stats := SelectProfiled(list, k)
+	slices.Sort(list)
+	if sel != list[k] {
+		t.Fatalf("got %d, want %d", sel, list[k])
+	}
+	if stats.Fallbacks == 0 {
+		t.Errorf("got %+v", stats)
+	}
+
+	for n := 1 << 12; n <= 1<<20; n <<= 2 {
+		list := permutation(n)
+		_, stats := SelectProfiled(list, n/3)
+		if stats.Partitions == 0 || stats.Comparisons > 8*n {
+			t.Errorf("n=%d: got %+v", n, stats)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package quick
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortStringsMSD(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// Short strings from a small alphabet,
+	// many of them prefixes of others, or equal.
+	short := make([]string, 100_000)
+	for i := range short {
+		b := make([]byte, rnd.Intn(8))
+		for j := range b {
+			b[j] = "ab\x00\xff"[rnd.Intn(4)]
+		}
+		short[i] = string(b)
+	}
+
+	sorted := paths(100_000)
+	slices.Sort(sorted)
+	equal := make([]string, 10_000)
+	for i := range equal {
+		equal[i] = "same"
+	}
+
+	tests := []struct {
+		name string
+		list []string
+	}{
+		{"empty", []string{}},
+		{"prefixes", []string{"abc", "ab", "", "abcd", "a", "abc", "b", "ab"}},
+		{"short", short},
+		{"paths", paths(100_000)},
+		{"sorted", sorted},
+		{"equal", equal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+			SortStringsMSD(tt.list)
+			if !slices.Equal(tt.list, want) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func BenchmarkSortStringsMSD(b *testing.B) {
+	src := paths(1_000_000)
+	list := make([]string, len(src))
+	b.Run("Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			Sort(list)
+		}
+	})
+	b.Run("SortStringsMSD", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortStringsMSD(list)
+		}
+	})
+}
+
+// Paths returns n file paths that share long prefixes.
+func paths(n int) []string {
+	dirs := []string{
+		"/home/user/projects/github.com/ncruces/sort/quick/",
+		"/home/user/projects/github.com/ncruces/sort/heap/",
+		"/home/user/projects/github.com/ncruces/sort/shell/",
+		"/home/user/projects/github.com/ncruces/go-sqlite3/vfs/",
+	}
+	list := make([]string, n)
+	for i, v := range permutation(n) {
+		list[i] = fmt.Sprintf("%s%s/file%08d.go", dirs[v%len(dirs)],
+			strings.Repeat("sub/", v%3), v)
+	}
+	return list
+}
@@ -0,0 +1,164 @@
+package quick
+
+// SortTable uses the Quicksort algorithm to sort n elements,
+// accessed only through the less and swap functions,
+// which are called with indices in [0, n).
+// This sorts data that isn't a slice, like a table stored
+// as a struct of slices (columns), sorted by a key column.
+// Like Sort, it avoids quadratic behavior by using Median-of-medians.
+// It uses O(n·log(n)) time and O(log(n)) space.
+func SortTable(n int, less func(i, j int) bool, swap func(i, j int)) {
+	table{less, swap}.sort(0, n)
+}
+
+// SortExternal sorts n elements, accessed only through the less and swap functions,
+// like SortTable, but calls swap as few times as possible:
+// for distinct elements, the minimum, n minus the number of cycles of the permutation.
+// This suits data where swaps are much more expensive than comparisons,
+// like data stored on disk.
+// It sorts the indices first, then follows the cycles of the permutation.
+// It uses O(n·log(n)) time and O(n) space.
+func SortExternal(n int, less func(i, j int) bool, swap func(i, j int)) {
+	x := make([]int, n)
+	for i := range x {
+		x[i] = i
+	}
+	// Equal elements stay in place.
+	SortFunc(x, func(i, j int) int {
+		switch {
+		case less(i, j):
+			return -1
+		case less(j, i):
+			return +1
+		default:
+			return i - j
+		}
+	})
+
+	// Position i must get the element at x[i].
+	for i := range x {
+		j := i
+		for x[j] != i {
+			k := x[j]
+			swap(j, k)
+			x[j] = j
+			j = k
+		}
+		x[j] = j
+	}
+}
+
+// A table sorts the range [lo, hi) by index.
+// As there's no way to hold on to a pivot value,
+// the pivot is kept at index lo while partitioning,
+// and then swapped into its final place.
+type table struct {
+	less func(i, j int) bool
+	swap func(i, j int)
+}
+
+func (t table) sort(lo, hi int) {
+	for hi-lo > minLen {
+		p := t.partition(lo, hi)
+		// The pivot is in its final place, at p.
+		if p-lo > hi-p {
+			t.sort(p+1, hi)
+			hi = p
+		} else {
+			t.sort(lo, p)
+			lo = p + 1
+		}
+	}
+	t.insertion(lo, hi)
+}
+
+func (t table) selectK(lo, hi, k int) {
+	for hi-lo > minLen {
+		p := t.partition(lo, hi)
+		switch {
+		case k < p:
+			hi = p
+		case k > p:
+			lo = p + 1
+		default:
+			return
+		}
+	}
+	t.insertion(lo, hi)
+}
+
+func (t table) partition(lo, hi int) int {
+	r := hi - 1
+	m := lo + (r-lo)/2
+
+	if r-lo >= minMed3 {
+		if t.less(r, lo) {
+			t.swap(lo, r)
+		}
+		if t.less(m, lo) {
+			t.swap(lo, m)
+		}
+		if t.less(r, m) {
+			t.swap(r, m)
+		}
+	}
+
+	t.swap(lo, m)
+	i := t.hoarePartition(lo, hi)
+
+	if r-lo >= minMedMed {
+		b := (r - lo) / minRatio
+		if !(lo+b < i && i < r-b) {
+			t.medianOfMedians(lo, hi)
+			i = t.hoarePartition(lo, hi)
+		}
+	}
+	return i
+}
+
+// HoarePartition partitions around the pivot at index lo,
+// and then swaps the pivot into place, returning its index.
+func (t table) hoarePartition(lo, hi int) int {
+	i := lo + 1
+	j := hi - 1
+	for {
+		for i <= j && t.less(i, lo) {
+			i += 1
+		}
+		for i <= j && t.less(lo, j) {
+			j -= 1
+		}
+		if i > j {
+			break
+		}
+		t.swap(i, j)
+		i += 1
+		j -= 1
+	}
+	t.swap(lo, j)
+	return j
+}
+
+func (t table) insertion(lo, hi int) {
+	for i := lo + 1; i < hi; i += 1 {
+		for j := i; j > lo && t.less(j, j-1); j -= 1 {
+			t.swap(j, j-1)
+		}
+	}
+}
+
+// MedianOfMedians moves a good pivot to index lo.
+func (t table) medianOfMedians(lo, hi int) {
+	m := lo
+	for i := lo; i+5 < hi; i += 5 {
+		t.insertion(i, i+5)
+		t.swap(m, i+2)
+		m += 1
+	}
+	if m-lo < 2 {
+		return
+	}
+	k := lo + (m-lo)/2
+	t.selectK(lo, m, k)
+	t.swap(lo, k)
+}
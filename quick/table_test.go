@@ -0,0 +1,133 @@
+package quick
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestSortTable(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"killer", killer(128*1024 - 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// A table with three columns, sorted by key.
+			key := tt.list
+			pos := sorted(len(key))
+			str := make([]string, len(key))
+			for i, k := range key {
+				str[i] = strconv.Itoa(k)
+			}
+
+			SortTable(len(key),
+				func(i, j int) bool { return key[i] < key[j] },
+				func(i, j int) {
+					key[i], key[j] = key[j], key[i]
+					pos[i], pos[j] = pos[j], pos[i]
+					str[i], str[j] = str[j], str[i]
+				})
+
+			if !slices.IsSorted(key) {
+				t.FailNow()
+			}
+			for i, k := range key {
+				if str[i] != strconv.Itoa(k) {
+					t.FailNow()
+				}
+			}
+			slices.Sort(pos)
+			if !slices.Equal(pos, sorted(len(key))) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestTableSelect(t *testing.T) {
+	s := permutation(100_000)
+	tbl := table{
+		func(i, j int) bool { return s[i] < s[j] },
+		func(i, j int) { s[i], s[j] = s[j], s[i] },
+	}
+	tbl.selectK(0, len(s), 1111)
+	if s[1111] != 1111 {
+		t.FailNow()
+	}
+}
+
+func BenchmarkSortTable(b *testing.B) {
+	list := killer(1024*1024 - 1)
+	b.ResetTimer()
+	SortTable(len(list),
+		func(i, j int) bool { return list[i] < list[j] },
+		func(i, j int) { list[i], list[j] = list[j], list[i] })
+}
+
+func BenchmarkSortSlice(b *testing.B) {
+	list := killer(1024*1024 - 1)
+	b.ResetTimer()
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+}
+
+func TestSortExternal(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := tt.list
+			want := cycles(list)
+
+			var swaps int
+			SortExternal(len(list),
+				func(i, j int) bool { return list[i] < list[j] },
+				func(i, j int) {
+					swaps += 1
+					list[i], list[j] = list[j], list[i]
+				})
+
+			if !slices.IsSorted(list) {
+				t.FailNow()
+			}
+			if swaps > len(list)-want {
+				t.Errorf("got %d swaps, want %d", swaps, len(list)-want)
+			}
+		})
+	}
+}
+
+// Cycles counts the cycles of the permutation that sorts s.
+func cycles(s []int) int {
+	x := sorted(len(s))
+	slices.SortStableFunc(x, func(i, j int) int { return s[i] - s[j] })
+
+	var n int
+	for i := range x {
+		if x[i] < 0 {
+			continue
+		}
+		n += 1
+		for j := i; x[j] >= 0; {
+			x[j], j = -1, x[j]
+		}
+	}
+	return n
+}
@@ -0,0 +1,95 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TopNWithTies rearranges a slice so that it starts with its n smallest elements,
+// followed by any other elements equal to the largest of those,
+// in sorted order, and returns them as a subslice of s.
+// It uses O(len(s) + n·log(n)) time and O(log(n)) space.
+func TopNWithTies[T cmp.Ordered](s []T, n int) []T {
+	// This does a bounds check before making any changes to the slice.
+	_ = s[:n]
+
+	if n == 0 {
+		return s[:0]
+	}
+
+	// Partition what follows the selection around it,
+	// moving ties to the front.
+	v := Select(s, n-1)
+	i := n
+	for j := n; j < len(s); j += 1 {
+		if !cmp.Less(v, s[j]) {
+			s[i], s[j] = s[j], s[i]
+			i += 1
+		}
+	}
+	Sort(s[:n])
+	return s[:i]
+}
+
+// A TopK keeps the k smallest of the elements added to it,
+// in a bounded binary max-heap.
+type TopK[T cmp.Ordered] struct {
+	k int
+	s []T
+}
+
+// NewTopK returns an empty TopK that keeps k elements.
+func NewTopK[T cmp.Ordered](k int) *TopK[T] {
+	k = max(k, 0)
+	return &TopK[T]{k: k, s: make([]T, 0, k)}
+}
+
+// Add offers an element to the TopK,
+// which keeps it if it's among the k smallest so far.
+// It uses O(log(k)) time and O(1) space.
+func (t *TopK[T]) Add(v T) {
+	s := t.s
+	switch {
+	case len(s) < t.k:
+		s = append(s, v)
+		t.s = s
+		// Sift up.
+		i := len(s) - 1
+		for i > 0 {
+			p := (i - 1) / 2
+			if !cmp.Less(s[p], s[i]) {
+				break
+			}
+			s[p], s[i] = s[i], s[p]
+			i = p
+		}
+	case len(s) > 0 && cmp.Less(v, s[0]):
+		s[0] = v
+		// Sift down.
+		i := 0
+		for {
+			m := i
+			if l := 2*i + 1; l < len(s) && cmp.Less(s[m], s[l]) {
+				m = l
+			}
+			if r := 2*i + 2; r < len(s) && cmp.Less(s[m], s[r]) {
+				m = r
+			}
+			if m == i {
+				break
+			}
+			s[i], s[m] = s[m], s[i]
+			i = m
+		}
+	}
+}
+
+// Result returns the k smallest elements added so far, in sorted order,
+// or all of them, if fewer than k were added.
+// It returns a copy, so the TopK can keep going.
+// It uses O(k·log(k)) time and O(k) space.
+func (t *TopK[T]) Result() []T {
+	r := slices.Clone(t.s)
+	Sort(r)
+	return r
+}
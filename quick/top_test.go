@@ -0,0 +1,74 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestTopNWithTies(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+		n    int
+		want []int
+	}{
+		{"empty", nil, 0, []int{}},
+		{"zero", []int{1, 2}, 0, []int{}},
+		{"all", []int{3, 1, 2}, 3, []int{1, 2, 3}},
+		{"no ties", []int{5, 3, 1, 4, 2}, 2, []int{1, 2}},
+		{"ties", []int{5, 2, 1, 2, 4, 2, 2}, 2, []int{1, 2, 2, 2, 2}},
+		{"equal", []int{7, 7, 7, 7}, 1, []int{7, 7, 7, 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TopNWithTies(tt.list, tt.n); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	list := bits(100_000)
+	for i := range list {
+		list[i] += i % 1000
+	}
+	want := slices.Clone(list)
+	slices.Sort(want)
+
+	got := TopNWithTies(list, 1111)
+	end := 1111
+	for end < len(want) && want[end] == want[1110] {
+		end += 1
+	}
+	if !slices.Equal(got, want[:end]) {
+		t.Errorf("got %d elements, want %d", len(got), end)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	list := floats(100_000)
+	want := slices.Clone(list)
+	slices.Sort(want)
+
+	for _, k := range []int{0, 1, 10, 1000} {
+		top := NewTopK[float64](k)
+		for i, v := range list {
+			top.Add(v)
+			if i == k/2 {
+				early := sortedCopy(list[:i+1])
+				if got := top.Result(); !slices.Equal(got, early[:min(k, len(early))]) {
+					t.Fatalf("k=%d: got %v early on", k, got)
+				}
+			}
+		}
+		if got := top.Result(); !slices.Equal(got, want[:k]) {
+			t.Fatalf("k=%d: got %v", k, got)
+		}
+	}
+}
+
+func sortedCopy[T cmp.Ordered](s []T) []T {
+	s = slices.Clone(s)
+	slices.Sort(s)
+	return s
+}
@@ -0,0 +1,128 @@
+package quick
+
+// A Tracer receives events from the Func variants,
+// for instance, to visualize how they work.
+// Any of its functions may be nil.
+// Indices are relative to the slice passed to the Func variant.
+type Tracer[T any] struct {
+	// OnPivot is called after each partition, with the pivot
+	// and the index where the partition splits:
+	// elements before it aren't greater than the pivot,
+	// elements from it on aren't less.
+	OnPivot func(value T, index int)
+	// OnSwap is called after two elements swap places.
+	// Insertion sort shifts elements, rather than swap them,
+	// but reports the equivalent swaps.
+	OnSwap func(i, j int)
+	// OnCompare is called before two elements are compared.
+	// The pivot is held aside, at index -1.
+	OnCompare func(i, j int)
+}
+
+// WithTrace reports events to t.
+// It only affects sorts of []T that don't use WithStableTies or WithIndirectSort.
+// With WithParallel, t's functions may be called concurrently.
+func WithTrace[T any](t Tracer[T]) Option {
+	return func(c *config) { c.tracer = t }
+}
+
+func (q *sorter[T]) swap(s []T, i, j int) {
+	s[i], s[j] = s[j], s[i]
+	q.traceSwap(s, i, j)
+}
+
+func (q *sorter[T]) lessAt(s []T, a, b T, i, j int) bool {
+	if q.trace != nil && q.trace.OnCompare != nil {
+		o := q.start(s)
+		if i >= 0 {
+			i += o
+		}
+		if j >= 0 {
+			j += o
+		}
+		q.trace.OnCompare(i, j)
+	}
+	return q.less(a, b)
+}
+
+func (q *sorter[T]) traceSwap(s []T, i, j int) {
+	q.countSwaps(1)
+	if q.trace != nil && q.trace.OnSwap != nil {
+		o := q.start(s)
+		q.trace.OnSwap(o+i, o+j)
+	}
+}
+
+func (q *sorter[T]) tracePivot(s []T, p T, i int) {
+	if q.trace != nil && q.trace.OnPivot != nil {
+		q.trace.OnPivot(p, q.start(s)+i)
+	}
+}
+
+// CountSwaps adds n swaps to the profile, if any.
+func (q *sorter[T]) countSwaps(n int) {
+	if p := q.profile; p != nil {
+		p.Swaps += n
+	}
+}
+
+// HoarePartitionTraced is hoarePartition, reporting events to the tracer.
+func (q *sorter[T]) hoarePartitionTraced(s []T, p T) int {
+	r := len(s) - 1
+	i := 0
+	j := r
+	for {
+		for i < r && q.lessAt(s, s[i], p, i, -1) {
+			i += 1
+		}
+		for j > 0 && q.lessAt(s, p, s[j], -1, j) {
+			j -= 1
+		}
+		if i > j {
+			return i
+		}
+		if i < j {
+			q.swap(s, i, j)
+		}
+		i += 1
+		j -= 1
+	}
+}
+
+// InsertionTraced is insertion, reporting events to the tracer.
+func (q *sorter[T]) insertionTraced(s []T) {
+	for i, p := range s {
+		j := i
+		for j > 0 && q.lessAt(s, p, s[j-1], j, j-1) {
+			s[j] = s[j-1]
+			q.traceSwap(s, j-1, j)
+			j -= 1
+		}
+		if j != i {
+			s[j] = p
+		}
+	}
+}
+
+// SelectionTraced is selection, reporting events to the tracer.
+func (q *sorter[T]) selectionTraced(s []T, k int) {
+	for i, p := range s[:k] {
+		m := 0
+		for j, v := range s[i:] {
+			if q.lessAt(s, v, p, i+j, i+m) {
+				m = j
+				p = v
+			}
+		}
+		if m != 0 {
+			q.swap(s, i, m+i)
+		}
+	}
+}
+
+// Start returns the index where s starts in the original slice.
+// Subslices are taken from the original slice by reslicing,
+// so this is the difference in their capacities.
+func (q *sorter[T]) start(s []T) int {
+	return q.base - cap(s)
+}
@@ -0,0 +1,54 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestWithTrace(t *testing.T) {
+	list := permutation(500)
+	shadow := slices.Clone(list)
+
+	var pivots, swaps, compares int
+	tracer := Tracer[int]{
+		OnPivot: func(value, index int) {
+			pivots += 1
+			if index <= 0 || index >= len(shadow) || !slices.Contains(shadow, value) {
+				t.Fatalf("bad pivot %d at %d", value, index)
+			}
+		},
+		OnSwap: func(i, j int) {
+			swaps += 1
+			if shadow[i] == shadow[j] {
+				t.Fatalf("swap(%d, %d) changes nothing", i, j)
+			}
+			shadow[i], shadow[j] = shadow[j], shadow[i]
+		},
+		OnCompare: func(i, j int) {
+			compares += 1
+			if i < -1 || j < -1 || i >= len(shadow) || j >= len(shadow) {
+				t.Fatalf("bad compare(%d, %d)", i, j)
+			}
+		},
+	}
+
+	SortWith(list, WithTrace(tracer))
+	if !slices.IsSorted(list) || !slices.Equal(list, shadow) {
+		t.Fatal("trace doesn't match the sort")
+	}
+	if pivots == 0 || swaps == 0 || compares == 0 {
+		t.Errorf("got %d pivots, %d swaps, %d compares", pivots, swaps, compares)
+	}
+
+	// Selecting traces too.
+	list = permutation(500)
+	shadow = slices.Clone(list)
+	SelectFunc(list, 100, cmp.Compare, WithTrace(tracer))
+	if !slices.Equal(list, shadow) {
+		t.Fatal("trace doesn't match the selection")
+	}
+
+	// Tracing a different type is a no-op.
+	SortWith([]float64{3, 2, 1}, WithTrace(tracer))
+}
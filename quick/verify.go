@@ -0,0 +1,111 @@
+package quick
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// VerifySorted panics if adjacent elements of s aren't in order,
+// or if comparing them in either order doesn't agree.
+func (q *sorter[T]) verifySorted(s []T) {
+	for i := 1; i < len(s); i += 1 {
+		a := q.cmp(s[i-1], s[i])
+		b := q.cmp(s[i], s[i-1])
+		if cmp.Compare(a, 0) != cmp.Compare(0, b) {
+			panic(fmt.Sprintf("quick: comparison function is not antisymmetric: "+
+				"cmp(s[%d], s[%d]) = %d, but cmp(s[%d], s[%d]) = %d", i-1, i, a, i, i-1, b))
+		}
+		if a > 0 {
+			panic(fmt.Sprintf("quick: slice is not sorted, comparison function is not transitive: "+
+				"cmp(s[%d], s[%d]) = %d", i-1, i, a))
+		}
+	}
+}
+
+const maxSample = 16
+
+// WithValidateDirection makes SortFunc check the comparison function
+// on a sample of the slice, before sorting it.
+// It panics if the comparison function is inconsistent,
+// or if it sorts a basic ordered type, like int or string,
+// in descending order:
+// rather than reversing the comparison function, use WithAscending(false).
+// It uses O(1) time.
+func WithValidateDirection() Option {
+	return func(c *config) { c.validateDirection = true }
+}
+
+// CheckDirection panics if, on a sample of s, the comparison function
+// isn't reflexive, antisymmetric, and transitive,
+// or disagrees with the natural order of T, without WithAscending(false).
+func (q *sorter[T]) checkDirection(s []T) {
+	var sample []T
+	for i := 0; i < maxSample && i < len(s); i += 1 {
+		sample = append(sample, s[i*len(s)/min(maxSample, len(s))])
+	}
+
+	agree, disagree := 0, 0
+	for i, a := range sample {
+		if c := q.cmp(a, a); c != 0 {
+			panic(fmt.Sprintf("quick: comparison function is not reflexive: "+
+				"cmp(a, a) = %d", c))
+		}
+		for j, b := range sample {
+			ab := cmp.Compare(q.cmp(a, b), 0)
+			if ab != -cmp.Compare(q.cmp(b, a), 0) {
+				panic("quick: comparison function is not antisymmetric")
+			}
+			if n, ok := natural(a, b); ok && i < j && n != 0 {
+				if ab == n {
+					agree += 1
+				} else {
+					disagree += 1
+				}
+			}
+			for _, c := range sample {
+				if ab <= 0 && q.cmp(b, c) <= 0 && q.cmp(a, c) > 0 {
+					panic("quick: comparison function is not transitive")
+				}
+			}
+		}
+	}
+	if disagree > 0 && agree == 0 && !q.descending {
+		panic("quick: comparison function sorts in descending order; " +
+			"use WithAscending(false) if this is intended")
+	}
+}
+
+// Natural compares a and b in their natural order, if T is a basic ordered type.
+func natural[T any](a, b T) (int, bool) {
+	switch a := any(a).(type) {
+	case int:
+		return cmp.Compare(a, any(b).(int)), true
+	case int8:
+		return cmp.Compare(a, any(b).(int8)), true
+	case int16:
+		return cmp.Compare(a, any(b).(int16)), true
+	case int32:
+		return cmp.Compare(a, any(b).(int32)), true
+	case int64:
+		return cmp.Compare(a, any(b).(int64)), true
+	case uint:
+		return cmp.Compare(a, any(b).(uint)), true
+	case uint8:
+		return cmp.Compare(a, any(b).(uint8)), true
+	case uint16:
+		return cmp.Compare(a, any(b).(uint16)), true
+	case uint32:
+		return cmp.Compare(a, any(b).(uint32)), true
+	case uint64:
+		return cmp.Compare(a, any(b).(uint64)), true
+	case uintptr:
+		return cmp.Compare(a, any(b).(uintptr)), true
+	case float32:
+		return cmp.Compare(a, any(b).(float32)), true
+	case float64:
+		return cmp.Compare(a, any(b).(float64)), true
+	case string:
+		return cmp.Compare(a, any(b).(string)), true
+	}
+	return 0, false
+}
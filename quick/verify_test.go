@@ -0,0 +1,88 @@
+package quick
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithVerify(t *testing.T) {
+	list := permutation(100_000)
+	SortWith(list, WithVerify())
+	if !slices.IsSorted(list) {
+		t.FailNow()
+	}
+
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "not antisymmetric") {
+			t.Errorf("got %q", msg)
+		}
+	}()
+	SortFunc(list[:100], func(a, b int) int { return -1 }, WithVerify())
+}
+
+func TestVerifySorted(t *testing.T) {
+	defer func() {
+		msg := fmt.Sprint(recover())
+		if !strings.Contains(msg, "not sorted") {
+			t.Errorf("got %q", msg)
+		}
+	}()
+	q := newSorter(cmp.Compare[int], nil)
+	q.verifySorted([]int{1, 2, 4, 3})
+}
+
+func TestWithValidateDirection(t *testing.T) {
+	list := permutation(100_000)
+	SortWith(list, WithValidateDirection())
+	SortWith(list, WithValidateDirection(), WithAscending(false))
+	SortFunc(records(1000, 10), byKey, WithValidateDirection())
+
+	tests := []struct {
+		name string
+		cmp  func(a, b int) int
+		msg  string
+	}{
+		{"reversed", func(a, b int) int { return cmp.Compare(b, a) }, "descending"},
+		{"less", func(a, b int) int { return boolCompare(a > b, false) }, "antisymmetric"},
+		{"always", func(a, b int) int { return -1 }, "reflexive"},
+		{"modular", func(a, b int) int { return [3]int{0, -1, +1}[((a-b)%3+3)%3] }, "transitive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, tt.msg) {
+					t.Errorf("got %q", msg)
+				}
+			}()
+			SortFunc(sorted(1000), tt.cmp, WithValidateDirection())
+		})
+	}
+
+	// Every basic ordered type has a natural order to check against.
+	for i, sort := range []func(){
+		func() { SortFunc([]int8{1, 2, 3}, descending[int8], WithValidateDirection()) },
+		func() { SortFunc([]int16{1, 2, 3}, descending[int16], WithValidateDirection()) },
+		func() { SortFunc([]uint8{1, 2, 3}, descending[uint8], WithValidateDirection()) },
+		func() { SortFunc([]uint16{1, 2, 3}, descending[uint16], WithValidateDirection()) },
+		func() { SortFunc([]uintptr{1, 2, 3}, descending[uintptr], WithValidateDirection()) },
+		func() { SortFunc([]float32{1, 2, 3}, descending[float32], WithValidateDirection()) },
+		func() { SortFunc([]string{"a", "b", "c"}, descending[string], WithValidateDirection()) },
+	} {
+		func() {
+			defer func() {
+				msg := fmt.Sprint(recover())
+				if !strings.Contains(msg, "descending") {
+					t.Errorf("%d: got %q", i, msg)
+				}
+			}()
+			sort()
+		}()
+	}
+}
+
+func descending[T cmp.Ordered](a, b T) int { return cmp.Compare(b, a) }
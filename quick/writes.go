@@ -0,0 +1,24 @@
+package quick
+
+// WithMinimalWrites makes SortFunc check if each subslice is already sorted,
+// before partitioning it, and if so, leave it alone.
+// Re-sorting sorted data then takes O(n) time, and writes nothing,
+// which matters for memory-mapped storage, for instance.
+// Partitioning still moves elements that are in place,
+// so nearly sorted data benefits less.
+// The checks stop at the first descending pair,
+// so they're cheap for unsorted subslices.
+func WithMinimalWrites() Option {
+	return func(c *config) { c.minWrites = true }
+}
+
+// IsSorted checks if s is sorted.
+// It uses O(n) time and O(1) space.
+func (q *sorter[T]) isSorted(s []T) bool {
+	for i := 1; i < len(s); i += 1 {
+		if q.lessAt(s, s[i], s[i-1], i, i-1) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package quick
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestWithMinimalWrites(t *testing.T) {
+	tests := []struct {
+		name   string
+		list   []int
+		writes bool
+	}{
+		{"zeros", zeros(100_000), false},
+		{"sorted", sorted(100_000), false},
+		{"spikes", spikes(100_000, 100), true},
+		{"permutation", permutation(100_000), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Insertion sort shifts, rather than swaps, elements,
+			// but writes back only those that move, so only shifts are traced.
+			var writes, compares int
+			tracer := WithTrace(Tracer[int]{
+				OnSwap:    func(i, j int) { writes += 1 },
+				OnCompare: func(i, j int) { compares += 1 },
+			})
+
+			SortFunc(tt.list, cmp.Compare, WithMinimalWrites(), tracer)
+			if !slices.IsSorted(tt.list) {
+				t.Fatal("not sorted")
+			}
+			if !tt.writes && (writes > 0 || compares >= len(tt.list)) {
+				t.Errorf("got %d writes, %d compares", writes, compares)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package shell
+
+// SortFunc uses the Shellsort algorithm to sort a slice,
+// as determined by the cmp function.
+// It uses O(1) space, and (empirically) about O(n^(4/3)) time.
+func SortFunc[T any](s []T, cmp func(a, b T) int) {
+	for i := len(gaps) - 1; i >= 0; i -= 1 {
+		if h := gaps[i]; h < len(s) {
+			insertionFunc(s, h, cmp)
+		}
+	}
+}
+
+func insertionFunc[T any](s []T, h int, cmp func(a, b T) int) {
+	for i := h; i < len(s); i += 1 {
+		p := s[i]
+		j := i
+		for j >= h && cmp(p, s[j-h]) < 0 {
+			s[j] = s[j-h]
+			j -= h
+		}
+		s[j] = p
+	}
+}
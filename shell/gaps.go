@@ -0,0 +1,71 @@
+package shell
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SortWith uses the Shellsort algorithm to sort a slice,
+// with the given gap sequence, like those from Hibbard or Pratt,
+// rather than Ciura's.
+// The gaps must be strictly descending, and end at 1;
+// it panics otherwise, before making any changes to the slice.
+// Its time complexity depends on the gap sequence.
+// It uses O(1) space.
+func SortWith[T cmp.Ordered](s []T, gaps []int) {
+	if len(gaps) == 0 || gaps[len(gaps)-1] != 1 {
+		panic("shell: gap sequence must end at 1")
+	}
+	for i := 1; i < len(gaps); i += 1 {
+		if gaps[i] >= gaps[i-1] {
+			panic("shell: gap sequence must be strictly descending")
+		}
+	}
+	for _, h := range gaps {
+		if h < len(s) {
+			insertion(s, h)
+		}
+	}
+}
+
+// Hibbard returns Thomas Hibbard's gap sequence, 2ᵏ-1,
+// for a slice of n elements: gaps less than n (but at least 1),
+// in descending order.
+// Shellsort with these gaps takes O(n^(3/2)) time, in the worst case.
+func Hibbard(n int) []int {
+	gaps := []int{1}
+	for h := 3; h < n; h = 2*h + 1 {
+		gaps = append(gaps, h)
+		// Stop before 2*h+1 overflows.
+		if h > (n-1)/2 {
+			break
+		}
+	}
+	slices.Reverse(gaps)
+	return gaps
+}
+
+// Pratt returns Vaughan Pratt's gap sequence, 2ᵖ·3ᵍ (the 3-smooth numbers),
+// for a slice of n elements: gaps less than n (but at least 1),
+// in descending order.
+// Shellsort with these gaps takes O(n·log²(n)) time, even in the worst case,
+// the best bound known for Shellsort,
+// but it uses O(log²(n)) gaps, so it's slower than others in practice.
+func Pratt(n int) []int {
+	var gaps []int
+	for p := 1; p == 1 || p < n; p *= 2 {
+		for h := p; h == 1 || h < n; h *= 3 {
+			gaps = append(gaps, h)
+			// Stop before 3*h overflows.
+			if h > n/3 {
+				break
+			}
+		}
+		if p > n/2 {
+			break
+		}
+	}
+	slices.Sort(gaps)
+	slices.Reverse(gaps)
+	return gaps
+}
@@ -0,0 +1,101 @@
+package shell
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestGaps(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 1000, math.MaxInt32, math.MaxInt} {
+		for name, gaps := range map[string][]int{
+			"Hibbard": Hibbard(n),
+			"Pratt":   Pratt(n),
+		} {
+			if len(gaps) == 0 || gaps[len(gaps)-1] != 1 {
+				t.Fatalf("%s(%d) = %v: doesn't end at 1", name, n, gaps)
+			}
+			if gaps[0] >= max(n, 2) {
+				t.Fatalf("%s(%d) = %v: gap too large", name, n, gaps)
+			}
+			for i := 1; i < len(gaps); i++ {
+				if gaps[i] >= gaps[i-1] {
+					t.Fatalf("%s(%d) = %v: not descending", name, n, gaps)
+				}
+			}
+		}
+	}
+
+	if got, want := Hibbard(100), []int{63, 31, 15, 7, 3, 1}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := Pratt(20), []int{18, 16, 12, 9, 8, 6, 4, 3, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestSortWith(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, gaps := range [][]int{Hibbard(len(tt.list)), Pratt(len(tt.list))} {
+				list := slices.Clone(tt.list)
+				SortWith(list, gaps)
+				if !slices.IsSorted(list) {
+					t.Fatalf("gaps %v: not sorted", gaps)
+				}
+			}
+
+			// A single gap of 1 is Insertion sort, which is quadratic.
+			list := slices.Clone(tt.list[:1000])
+			SortWith(list, []int{1})
+			if !slices.IsSorted(list) {
+				t.Fatal("gaps [1]: not sorted")
+			}
+		})
+	}
+
+	for _, gaps := range [][]int{nil, {4, 2}, {1, 4, 1}, {4, 4, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("gaps %v: didn't panic", gaps)
+				}
+			}()
+			SortWith([]int{2, 1}, gaps)
+		}()
+	}
+}
+
+func BenchmarkSortWith(b *testing.B) {
+	src := reversed(1_000_000)
+	list := make([]int, len(src))
+	b.Run("Ciura", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			Sort(list)
+		}
+	})
+	b.Run("Hibbard", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortWith(list, Hibbard(len(list)))
+		}
+	})
+	b.Run("Pratt", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(list, src)
+			SortWith(list, Pratt(len(list)))
+		}
+	})
+}
@@ -0,0 +1,78 @@
+// Package shell implements Donald Shell's Shellsort.
+//
+// This package uses Marcin Ciura's gap sequence,
+// extended geometrically for larger slices.
+package shell
+
+import (
+	"cmp"
+	"math"
+)
+
+// Ciura's gaps were found experimentally, up to 1750.
+// Beyond that, each gap is 2.25 times the previous one.
+var gaps = func() []int {
+	g := []int{1, 4, 10, 23, 57, 132, 301, 701, 1750}
+	// Stop before h*9 overflows.
+	for h := g[len(g)-1]; h <= math.MaxInt/9; {
+		h = h * 9 / 4
+		g = append(g, h)
+	}
+	return g
+}()
+
+// Sort uses the Shellsort algorithm to sort a slice.
+// It uses O(1) space, and (empirically) about O(n^(4/3)) time.
+func Sort[T cmp.Ordered](s []T) {
+	for i := len(gaps) - 1; i >= 0; i -= 1 {
+		if h := gaps[i]; h < len(s) {
+			insertion(s, h)
+		}
+	}
+}
+
+// SortFirst sorts the first k elements of a slice.
+// Shellsort doesn't partition, so running the gap sequence over the whole slice
+// would be no cheaper than a full sort.
+// Instead, this sorts s[:k], then inserts into it only those elements
+// of s[k:] that belong there, displacing the largest of s[:k].
+// It uses O(n·k) time and O(1) space,
+// but only O(n + k^(4/3)) time on nearly sorted slices.
+func SortFirst[T cmp.Ordered](s []T, k int) {
+	// This does a bounds check before making any changes to the slice.
+	_ = s[:k]
+
+	if k == 0 {
+		return
+	}
+	Sort(s[:k])
+	for i := k; i < len(s); i += 1 {
+		p := s[i]
+		if !cmp.Less(p, s[k-1]) {
+			continue
+		}
+		s[i] = s[k-1]
+		j := k - 1
+		for j > 0 && cmp.Less(p, s[j-1]) {
+			s[j] = s[j-1]
+			j -= 1
+		}
+		s[j] = p
+	}
+}
+
+// Insertion sorts elements h apart (h-sorting),
+// and is the core of the Shellsort algorithm.
+// The last pass (h=1) is a plain Insertion sort,
+// which is fast because the slice is nearly sorted by then.
+func insertion[T cmp.Ordered](s []T, h int) {
+	for i := h; i < len(s); i += 1 {
+		p := s[i]
+		j := i
+		for j >= h && cmp.Less(p, s[j-h]) {
+			s[j] = s[j-h]
+			j -= h
+		}
+		s[j] = p
+	}
+}
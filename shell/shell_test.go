@@ -0,0 +1,129 @@
+package shell
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(1_000_000)},
+		{"bits", bits(1_000_000)},
+		{"sorted", sorted(1_000_000)},
+		{"reversed", reversed(1_000_000)},
+		{"pipeorgan", pipeorgan(1_000_000)},
+		{"permutation", permutation(1_000_000)},
+		{"nearly", nearly(1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Sort(tt.list)
+			if !slices.IsSorted(tt.list) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestSortFirst(t *testing.T) {
+	tests := []struct {
+		name string
+		list []int
+	}{
+		{"zeros", zeros(100_000)},
+		{"bits", bits(100_000)},
+		{"sorted", sorted(100_000)},
+		{"reversed", reversed(100_000)},
+		{"pipeorgan", pipeorgan(100_000)},
+		{"permutation", permutation(100_000)},
+		{"nearly", nearly(1_000_000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := slices.Clone(tt.list)
+			slices.Sort(want)
+
+			SortFirst(tt.list, 1111)
+			if !slices.Equal(tt.list[:1111], want[:1111]) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	Sort[int](nil)
+	Sort([]int{0})
+
+	SortFirst[int](nil, 0)
+	SortFirst([]int{0}, 0)
+	SortFirst([]int{0}, 1)
+}
+
+func BenchmarkSort(b *testing.B) {
+	list := floats(10_000_000)
+	b.ResetTimer()
+	Sort(list)
+}
+
+func BenchmarkSortFirst(b *testing.B) {
+	list := nearly(10_000_000)
+	b.ResetTimer()
+	SortFirst(list, 1_000)
+}
+
+func zeros(n int) []int {
+	return make([]int, n)
+}
+
+func sorted(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+func reversed(n int) []int {
+	s := sorted(n)
+	slices.Reverse(s)
+	return s
+}
+
+func permutation(n int) []int {
+	return rand.Perm(n)
+}
+
+func bits(n int) []int {
+	s := rand.Perm(n)
+	for i := range s {
+		s[i] &= 1
+	}
+	return s
+}
+
+func floats(n int) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = rand.Float64()
+	}
+	return s
+}
+
+func pipeorgan(n int) []int {
+	return append(sorted(n/2), reversed(n/2)...)
+}
+
+func nearly(n int) []int {
+	s := sorted(n)
+	for k := 0; k < n/100; k++ {
+		i := rand.Intn(n)
+		j := rand.Intn(n)
+		s[i], s[j] = s[j], s[i]
+	}
+	return s
+}